@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// newEndpointOptions returns the fetcher options for the provider endpoint
+// base URLs overridden in the "endpoints" section of the config file.
+func newEndpointOptions() ([]ogp.FetcherOption, error) {
+	var opts []ogp.FetcherOption
+	for name, baseURL := range viper.GetStringMapString("endpoints") {
+		endpoint := ogp.Endpoint(name)
+		if !slices.Contains(ogp.Endpoints(), endpoint) {
+			return nil, fmt.Errorf("unknown endpoint %q", name)
+		}
+		u, err := url.Parse(strings.ReplaceAll(baseURL, "{host}", "example.org"))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("invalid URL for endpoint %s: %q", name, baseURL)
+		}
+		opts = append(opts, ogp.WithEndpoint(endpoint, baseURL))
+	}
+	return opts, nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/tro3373/ogp/pkg/format"
+)
+
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+)
+
+var (
+	feedFormat      string
+	feedTitle       string
+	feedLink        string
+	feedDescription string
+	feedAuthor      string
+)
+
+// feedCmd represents the feed command
+var feedCmd = &cobra.Command{
+	Use:   "feed",
+	Short: "Generate an RSS/Atom feed from a list of URLs",
+	Long: `feed fetches OpenGraph metadata for each URL and emits an RSS 2.0 or Atom document.
+URLs are read from stdin or command line arguments, the same way as the root command.
+
+Usage:
+  cat urls.txt | ogp feed --title "Weekly links" --link https://example.com/
+  cat urls.txt | ogp feed --format atom > links.atom`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := handleFeed(args); err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(feedCmd)
+
+	feedCmd.Flags().StringVarP(&feedFormat, "format", "f", feedFormatRSS, "feed format (rss, atom)")
+	feedCmd.Flags().StringVar(&feedTitle, "title", "ogp links", "feed title")
+	feedCmd.Flags().StringVar(&feedLink, "link", "", "feed link (site URL, default is the first item URL for rss)")
+	feedCmd.Flags().StringVar(&feedDescription, "description", "", "feed description")
+	feedCmd.Flags().StringVar(&feedAuthor, "author", "", "feed author (default is the host of --link)")
+}
+
+func handleFeed(args []string) error {
+	setupLog()
+
+	formatter, err := newFeedFormatter()
+	if err != nil {
+		return err
+	}
+
+	urls := getUrlsFromStdinOrArgs(args)
+	if len(urls) == 0 {
+		return fmt.Errorf("no url provided")
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	fetcher, err := newFetcher(client)
+	if err != nil {
+		return err
+	}
+	results := fetchAll(context.Background(), fetcher, urls)
+
+	log.Debug("Done")
+	return printResult(formatter, results)
+}
+
+func newFeedFormatter() (format.Formatter, error) {
+	feed := format.Feed{
+		Title:       feedTitle,
+		Link:        feedLink,
+		Description: feedDescription,
+		Author:      feedAuthor,
+	}
+	switch feedFormat {
+	case feedFormatRSS:
+		return &format.RSS{Feed: feed}, nil
+	case feedFormatAtom:
+		return &format.Atom{Feed: feed}, nil
+	}
+	return nil, fmt.Errorf("unknown feed format %q (available: %s, %s)", feedFormat, feedFormatRSS, feedFormatAtom)
+}
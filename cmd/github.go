@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// githubHostConfig is a GitHub Enterprise Server entry of "github.hosts".
+type githubHostConfig struct {
+	API   string
+	Token string
+}
+
+// newGitHubOptions returns the fetcher options for the GitHub Enterprise
+// Server hosts in "github.hosts", either a list of hosts using their default
+// API or a map of hosts to their API and token.
+func newGitHubOptions() ([]ogp.FetcherOption, error) {
+	if _, ok := viper.Get("github.hosts").([]any); ok {
+		return []ogp.FetcherOption{ogp.WithGitHubHosts(viper.GetStringSlice("github.hosts")...)}, nil
+	}
+	var hosts map[string]githubHostConfig
+	if err := viper.UnmarshalKey("github.hosts", &hosts); err != nil {
+		return nil, fmt.Errorf("invalid github.hosts config: %w", err)
+	}
+	opts := make([]ogp.FetcherOption, 0, len(hosts))
+	for host, cfg := range hosts {
+		opts = append(opts, ogp.WithGitHubHost(host, cfg.API, cfg.Token))
+	}
+	return opts, nil
+}
@@ -2,7 +2,7 @@ package cmd
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,36 +10,76 @@ import (
 	"sync"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/tro3373/ogp/external/shared"
+	"github.com/tro3373/ogp/pkg/archive"
+	"github.com/tro3373/ogp/pkg/format"
 	"github.com/tro3373/ogp/pkg/ogp"
 )
 
 const workers = 2
 
 func handle(args []string) error {
+	setupLog()
+	return handleArgs(args)
+}
+
+func setupLog() {
 	level, err := log.ParseLevel(os.Getenv("LOG_LEVEL"))
 	if err == nil {
 		log.SetLevel(level)
 	}
 	log.Debug("Debug start")
-
-	return handleArgs(args)
 }
 
 func handleArgs(args []string) error {
+	formatter, err := newFormatter()
+	if err != nil {
+		return err
+	}
+
 	urls := getUrlsFromStdinOrArgs(args)
 	if len(urls) == 0 {
 		return fmt.Errorf("no url provided")
 	}
 
-	apiClient := shared.NewAPIClient(
-		shared.WithDumpEnabled(log.GetLevel() >= log.DebugLevel),
-	)
-	fetcher := ogp.NewFetcher(&apiClientAdapter{client: apiClient})
-	results := fetchAll(fetcher, urls)
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	fetcher, err := newFetcher(client)
+	if err != nil {
+		return err
+	}
+	results := fetchAll(context.Background(), fetcher, urls)
+	if saveDir != "" {
+		saveImages(client, results)
+	}
 
 	log.Debug("Done")
-	return printResult(results)
+	if webhookURL != "" {
+		return postResult(client, formatter, results)
+	}
+	return printResult(formatter, results)
+}
+
+func newFormatter() (format.Formatter, error) {
+	opts := format.Options{HTMLPage: htmlPage}
+	if cardColor != "" {
+		color, err := format.ParseColor(cardColor)
+		if err != nil {
+			return nil, err
+		}
+		opts.Color = &color
+	}
+	formatter, err := format.New(outputName, opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := formatter.(format.ChatFormatter); webhookURL != "" && !ok {
+		return nil, fmt.Errorf("--webhook requires a chat output format (%s, %s)", format.SlackFormat, format.DiscordFormat)
+	}
+	return formatter, nil
 }
 
 func getUrlsFromStdinOrArgs(args []string) []string {
@@ -66,38 +106,101 @@ func getUrlsFromStdinOrArgs(args []string) []string {
 	return urls
 }
 
-func fetchAll(fetcher *ogp.Fetcher, urls []string) []*ogp.Result {
-	var (
-		wg      sync.WaitGroup
-		mu      sync.Mutex
-		results []*ogp.Result
-	)
+func fetchAll(ctx context.Context, fetcher *ogp.Fetcher, urls []string) []*ogp.Result {
+	var wg sync.WaitGroup
 
+	// results are stored by input index so that output order is stable
+	results := make([]*ogp.Result, len(urls))
 	sem := make(chan struct{}, workers)
 
-	for _, u := range urls {
+	for i, u := range urls {
 		wg.Add(1)
-		go func(url string) {
+		go func(i int, url string) {
 			defer wg.Done()
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
 			log.Debugf("Fetching URL: %s", url)
-			result := fetcher.Fetch(url)
+			result := fetcher.Fetch(ctx, url)
 			if result.Err != nil {
 				log.Warnf("Error fetching %s: %v", url, result.Err)
 			}
-
-			mu.Lock()
-			results = append(results, result)
-			mu.Unlock()
-		}(u)
+			results[i] = result
+		}(i, u)
 	}
 
 	wg.Wait()
 	return results
 }
 
+func newAPIClient(extra ...shared.APIClientOption) (*apiClientAdapter, error) {
+	opts := []shared.APIClientOption{
+		shared.WithDumpEnabled(log.GetLevel() >= log.DebugLevel),
+	}
+	if viper.GetBool("ssrf.enabled") {
+		policy, err := newSSRFPolicy()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, shared.WithSSRFProtection(policy))
+	}
+	return &apiClientAdapter{client: shared.NewAPIClient(append(opts, extra...)...)}, nil
+}
+
+func newFetcher(client ogp.HTTPClient) (*ogp.Fetcher, error) {
+	opts := []ogp.FetcherOption{
+		ogp.WithImageProbe(viper.GetBool("probe_images")),
+		ogp.WithImagePlaceholders(viper.GetBool("image_placeholders")),
+		ogp.WithPreferredIconSize(viper.GetInt("icon_size")),
+		ogp.WithContentExtraction(viper.GetBool("content.enabled")),
+		ogp.WithContentHTML(viper.GetBool("content.html")),
+		ogp.WithGitHubToken(viper.GetString("github.token")),
+		ogp.WithLinkedPreviews(viper.GetBool("linked_previews")),
+	}
+	for _, configured := range []func() ([]ogp.FetcherOption, error){
+		newPolicyOptions,
+		newRulesOptions,
+		newEndpointOptions,
+		newGitHubOptions,
+	} {
+		more, err := configured()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, more...)
+	}
+	return ogp.NewFetcher(client, opts...), nil
+}
+
+func newSSRFPolicy() (*shared.SSRFPolicy, error) {
+	allow, err := shared.ParseCIDRs(viper.GetStringSlice("ssrf.allow_cidrs"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssrf.allow_cidrs: %w", err)
+	}
+	deny, err := shared.ParseCIDRs(viper.GetStringSlice("ssrf.deny_cidrs"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssrf.deny_cidrs: %w", err)
+	}
+	return &shared.SSRFPolicy{
+		AllowCIDRs:     allow,
+		DenyCIDRs:      deny,
+		AllowedPorts:   viper.GetIntSlice("ssrf.allowed_ports"),
+		AllowedSchemes: viper.GetStringSlice("ssrf.allowed_schemes"),
+	}, nil
+}
+
+func saveImages(client ogp.HTTPClient, results []*ogp.Result) {
+	saver := archive.New(client, saveDir,
+		archive.WithFavicon(saveIcon),
+		archive.WithThumbnailSize(thumbSize),
+	)
+	for _, r := range successfulResults(results) {
+		if err := saver.Save(r); err != nil {
+			log.Warnf("Error saving images of %s: %v", r.URL, err)
+		}
+	}
+}
+
 type apiClientAdapter struct {
 	client *shared.APIClient
 }
@@ -106,7 +209,23 @@ func (a *apiClientAdapter) Request(req *http.Request) ([]byte, int, error) {
 	return a.client.Request(req)
 }
 
-func printResult(results []*ogp.Result) error {
+func (a *apiClientAdapter) RequestPrefix(req *http.Request, n int64) ([]byte, int, error) {
+	return a.client.RequestPrefix(req, n)
+}
+
+func printResult(formatter format.Formatter, results []*ogp.Result) error {
+	return formatter.Format(os.Stdout, successfulResults(results))
+}
+
+func postResult(client ogp.HTTPClient, formatter format.Formatter, results []*ogp.Result) error {
+	payloads, err := formatter.(format.ChatFormatter).Payloads(successfulResults(results))
+	if err != nil {
+		return err
+	}
+	return format.PostWebhook(client, webhookURL, payloads)
+}
+
+func successfulResults(results []*ogp.Result) []*ogp.Result {
 	successful := make([]*ogp.Result, 0, len(results))
 	for _, r := range results {
 		if r.Err != nil {
@@ -114,16 +233,5 @@ func printResult(results []*ogp.Result) error {
 		}
 		successful = append(successful, r)
 	}
-
-	var target any = successful
-	if len(successful) == 1 {
-		target = successful[0]
-	}
-
-	output, err := json.MarshalIndent(target, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal result: %w", err)
-	}
-	fmt.Println(string(output))
-	return nil
+	return successful
 }
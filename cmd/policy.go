@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// policyConfig is the extraction policy section of the config file.
+type policyConfig struct {
+	Order   map[string][]string
+	Disable []string
+	Domains map[string]policyConfig
+}
+
+func (c policyConfig) policy() (ogp.Policy, error) {
+	p := ogp.Policy{Order: make(map[string][]ogp.Source, len(c.Order))}
+	for field, sources := range c.Order {
+		p.Order[field] = toSources(sources)
+	}
+	p.Disabled = toSources(c.Disable)
+	return p, p.Validate()
+}
+
+func toSources(names []string) []ogp.Source {
+	sources := make([]ogp.Source, len(names))
+	for i, name := range names {
+		sources[i] = ogp.Source(name)
+	}
+	return sources
+}
+
+// newPolicyOptions returns the fetcher options for the policy configured in
+// the "policy" section of the config file.
+func newPolicyOptions() ([]ogp.FetcherOption, error) {
+	var cfg policyConfig
+	if err := viper.UnmarshalKey("policy", &cfg); err != nil {
+		return nil, fmt.Errorf("invalid policy config: %w", err)
+	}
+
+	p, err := cfg.policy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy config: %w", err)
+	}
+	opts := []ogp.FetcherOption{ogp.WithPolicy(p)}
+	for domain, domainCfg := range cfg.Domains {
+		p, err := domainCfg.policy()
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy config for %s: %w", domain, err)
+		}
+		opts = append(opts, ogp.WithDomainPolicy(domain, p))
+	}
+	return opts, nil
+}
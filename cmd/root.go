@@ -24,13 +24,24 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/tro3373/ogp/pkg/format"
 )
 
-var cfgFile string
+var (
+	cfgFile    string
+	outputName string
+	htmlPage   bool
+	webhookURL string
+	cardColor  string
+	saveDir    string
+	saveIcon   bool
+	thumbSize  int
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -43,7 +54,12 @@ Twitter/X URLs are handled using the oEmbed API (no authentication required).
 Usage:
   ogp <url>                    Extract OGP from a single URL
   cat urls.txt | ogp           Extract OGP from multiple URLs via stdin
-  echo "https://example.com" | ogp`,
+  echo "https://example.com" | ogp
+  ogp -o markdown <url>        Render results as Markdown link cards
+  cat urls.txt | ogp -o html   Render results as HTML link cards (--html-page for a full page)
+  ogp -o slack --webhook <hook> <url>  Post results to a Slack/Discord incoming webhook`,
+	// URLs are positional arguments, so accept them alongside subcommands.
+	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := handle(args); err != nil {
 			log.Error(err)
@@ -69,10 +85,32 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.ogp)")
+	rootCmd.PersistentFlags().Bool("ssrf-protection", false, "refuse to connect to loopback, link-local, private and multicast addresses")
+	cobra.CheckErr(viper.BindPFlag("ssrf.enabled", rootCmd.PersistentFlags().Lookup("ssrf-protection")))
+	rootCmd.PersistentFlags().Bool("probe-images", false, "fetch candidate images to drop broken or tiny ones and pick the best preview image")
+	cobra.CheckErr(viper.BindPFlag("probe_images", rootCmd.PersistentFlags().Lookup("probe-images")))
+	rootCmd.PersistentFlags().Bool("image-placeholders", false, "compute the dominant color and BlurHash of the preview image")
+	cobra.CheckErr(viper.BindPFlag("image_placeholders", rootCmd.PersistentFlags().Lookup("image-placeholders")))
+	rootCmd.PersistentFlags().Int("icon-size", 64, "preferred icon size in pixels used to pick the result icon")
+	cobra.CheckErr(viper.BindPFlag("icon_size", rootCmd.PersistentFlags().Lookup("icon-size")))
+	rootCmd.PersistentFlags().Bool("content", false, "extract the main content with word count and reading time")
+	cobra.CheckErr(viper.BindPFlag("content.enabled", rootCmd.PersistentFlags().Lookup("content")))
+	rootCmd.PersistentFlags().Bool("content-html", false, "also include the sanitized HTML of the main content (with --content)")
+	cobra.CheckErr(viper.BindPFlag("content.html", rootCmd.PersistentFlags().Lookup("content-html")))
+	rootCmd.PersistentFlags().Bool("linked-previews", false, "also fetch the preview of the page a Reddit or Hacker News post links to")
+	cobra.CheckErr(viper.BindPFlag("linked_previews", rootCmd.PersistentFlags().Lookup("linked-previews")))
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	rootCmd.Flags().StringVarP(&outputName, "output", "o", format.JSONFormat,
+		fmt.Sprintf("output format (%s)", strings.Join(format.Names(), ", ")))
+	rootCmd.Flags().BoolVar(&htmlPage, "html-page", false, "wrap html output in a self-contained page with CSS")
+	rootCmd.Flags().StringVar(&webhookURL, "webhook", "", "post slack/discord output to this incoming webhook URL instead of printing it")
+	rootCmd.Flags().StringVar(&cardColor, "color", "", "accent color of Discord embeds as #RRGGBB (default is per site)")
+	rootCmd.Flags().StringVar(&saveDir, "save-images", "", "download preview images into this directory")
+	rootCmd.Flags().BoolVar(&saveIcon, "save-favicon", false, "also download the favicon (with --save-images)")
+	rootCmd.Flags().IntVar(&thumbSize, "thumbnail-size", 0, "generate thumbnails fitting within this many pixels (with --save-images)")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -93,6 +131,7 @@ func initConfig() {
 	}
 
 	viper.AutomaticEnv() // read in environment variables that match
+	cobra.CheckErr(viper.BindEnv("github.token", "GITHUB_TOKEN"))
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
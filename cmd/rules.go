@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tro3373/ogp/pkg/ogp"
+	"go.yaml.in/yaml/v3"
+)
+
+// newRulesOptions returns the fetcher options for the per-host extraction
+// rules configured in the "rules" section of the config file.
+func newRulesOptions() ([]ogp.FetcherOption, error) {
+	var rules map[string]ogp.Rules
+	if err := viper.UnmarshalKey("rules", &rules); err != nil {
+		return nil, fmt.Errorf("invalid rules config: %w", err)
+	}
+	names, err := readFieldNames(viper.ConfigFileUsed())
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules config: %w", err)
+	}
+
+	var opts []ogp.FetcherOption
+	for host, r := range rules {
+		r.Fields = restoreFieldNames(r.Fields, names[host])
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid rules for %s: %w", host, err)
+		}
+		opts = append(opts, ogp.WithRules(host, r))
+	}
+	return opts, nil
+}
+
+// readFieldNames reads the custom field names of every host's rules from the
+// config file, keyed by lower-cased host and field name. Viper lower-cases
+// map keys, so the names are taken from the raw YAML.
+func readFieldNames(path string) (map[string]map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var raw struct {
+		Rules map[string]struct {
+			Fields map[string]yaml.Node `yaml:"fields"`
+		} `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	names := make(map[string]map[string]string, len(raw.Rules))
+	for host, r := range raw.Rules {
+		host = strings.ToLower(host)
+		if names[host] == nil {
+			names[host] = make(map[string]string, len(r.Fields))
+		}
+		for name := range r.Fields {
+			names[host][strings.ToLower(name)] = name
+		}
+	}
+	return names, nil
+}
+
+// restoreFieldNames renames the lower-cased fields back to their names in
+// the config file.
+func restoreFieldNames(fields map[string]ogp.SelectorRule, names map[string]string) map[string]ogp.SelectorRule {
+	if len(fields) == 0 || len(names) == 0 {
+		return fields
+	}
+	restored := make(map[string]ogp.SelectorRule, len(fields))
+	for name, rule := range fields {
+		if original, ok := names[name]; ok {
+			name = original
+		}
+		restored[name] = rule
+	}
+	return restored
+}
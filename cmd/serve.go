@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tro3373/ogp/external/shared"
+	"github.com/tro3373/ogp/pkg/server"
+)
+
+var (
+	serveAddr            string
+	serveRequestTimeout  time.Duration
+	serveShutdownTimeout time.Duration
+	serveMaxBatch        int
+	serveImageDecodes    int
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a link-preview HTTP API",
+	Long: `serve runs an HTTP server exposing OpenGraph extraction as a JSON API.
+
+Endpoints:
+  GET  /v1/preview?url=<url>   Preview a single URL
+  POST /v1/preview             Preview a batch: {"urls": ["<url>", ...]}
+  GET  /v1/image?url=&w=&h=&f=&s=  Signed image proxy (with --image-proxy-secret)
+  GET  /healthz                Liveness probe
+  GET  /readyz                 Readiness probe (503 while shutting down)`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := handleServe(); err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "listen address")
+	serveCmd.Flags().DurationVar(&serveRequestTimeout, "timeout", 15*time.Second, "maximum time spent fetching per API request")
+	serveCmd.Flags().DurationVar(&serveShutdownTimeout, "shutdown-timeout", 10*time.Second, "how long to wait for in-flight requests on shutdown")
+	serveCmd.Flags().IntVar(&serveMaxBatch, "max-batch", 20, "maximum number of URLs per batch request")
+	serveCmd.Flags().String("image-proxy-secret", "", "HMAC secret enabling the signed image proxy endpoint")
+	cobra.CheckErr(viper.BindPFlag("image_proxy.secret", serveCmd.Flags().Lookup("image-proxy-secret")))
+	serveCmd.Flags().IntVar(&serveImageDecodes, "image-proxy-decodes", 2, "maximum number of images decoded concurrently by the image proxy")
+}
+
+func handleServe() error {
+	setupLog()
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	fetcher, err := newFetcher(client)
+	if err != nil {
+		return err
+	}
+	opts := []server.Option{
+		server.WithRequestTimeout(serveRequestTimeout),
+		server.WithShutdownTimeout(serveShutdownTimeout),
+		server.WithMaxBatch(serveMaxBatch),
+	}
+	if secret := viper.GetString("image_proxy.secret"); secret != "" {
+		imageClient, err := newAPIClient(shared.WithMaxResponseBytes(server.MaxImageBytes))
+		if err != nil {
+			return err
+		}
+		opts = append(opts,
+			server.WithImageProxy(imageClient, []byte(secret)),
+			server.WithImageDecodes(serveImageDecodes),
+		)
+	}
+	srv := server.New(fetcher, opts...)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return srv.ListenAndServe(ctx, serveAddr)
+}
@@ -27,6 +27,8 @@ type APIClient struct {
 	dumpEnabled  bool
 	dumpLogLevel slog.Level
 	dumpPretty   bool
+	ssrfPolicy   *SSRFPolicy
+	maxBodySize  int64
 }
 
 // APIClientOption applies a configuration to an APIClient.
@@ -47,6 +49,16 @@ func WithDumpPretty(pretty bool) APIClientOption {
 	return func(c *APIClient) { c.dumpPretty = pretty }
 }
 
+// ErrResponseTooLarge is returned when a response body exceeds the limit set
+// with WithMaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// WithMaxResponseBytes makes the client stop reading response bodies larger
+// than n bytes and return ErrResponseTooLarge instead.
+func WithMaxResponseBytes(n int64) APIClientOption {
+	return func(c *APIClient) { c.maxBodySize = n }
+}
+
 // RequestOption applies a modification to an http.Request before it is sent.
 type RequestOption func(req *http.Request)
 
@@ -72,10 +84,25 @@ func NewAPIClient(opts ...APIClientOption) *APIClient {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.ssrfPolicy != nil {
+		c.ssrfPolicy.apply(c.Client)
+	}
 	return c
 }
 
 func (c *APIClient) Request(req *http.Request, opts ...RequestOption) ([]byte, int, error) {
+	return c.request(req, false, c.maxBodySize, opts)
+}
+
+// RequestPrefix sends req like Request but reads only the first n bytes of
+// the response body, dropping the rest.
+func (c *APIClient) RequestPrefix(req *http.Request, n int64, opts ...RequestOption) ([]byte, int, error) {
+	return c.request(req, true, n, opts)
+}
+
+// request sends req and reads up to limit bytes of the body. Longer bodies
+// are truncated when prefix is set and rejected otherwise.
+func (c *APIClient) request(req *http.Request, prefix bool, limit int64, opts []RequestOption) ([]byte, int, error) {
 	// Option適用
 	for _, opt := range opts {
 		opt(req)
@@ -85,8 +112,17 @@ func (c *APIClient) Request(req *http.Request, opts ...RequestOption) ([]byte, i
 		c.DumpRequest(req)
 	}
 
+	if c.ssrfPolicy != nil {
+		if err := c.ssrfPolicy.CheckURL(req.URL); err != nil {
+			return nil, http.StatusForbidden, err
+		}
+	}
+
 	res, err := c.Client.Do(req)
 	if err != nil {
+		if errors.Is(err, ErrSSRFBlocked) {
+			return nil, http.StatusForbidden, err
+		}
 		var ne net.Error
 		if ok := errors.As(err, &ne); ok {
 			return nil, http.StatusRequestTimeout, err
@@ -100,10 +136,23 @@ func (c *APIClient) Request(req *http.Request, opts ...RequestOption) ([]byte, i
 		}
 	}()
 
-	body, err := io.ReadAll(res.Body)
+	var reader io.Reader = res.Body
+	switch {
+	case prefix:
+		reader = io.LimitReader(res.Body, limit)
+	case limit > 0:
+		if res.ContentLength > limit {
+			return nil, res.StatusCode, fmt.Errorf("%w: %d bytes", ErrResponseTooLarge, res.ContentLength)
+		}
+		reader = io.LimitReader(res.Body, limit+1)
+	}
+	body, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
+	if !prefix && limit > 0 && int64(len(body)) > limit {
+		return nil, res.StatusCode, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
 	if c.dumpEnabled {
 		c.DumpResponse(res.StatusCode, body)
 	}
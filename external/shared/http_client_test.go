@@ -109,6 +109,41 @@ func TestAPIClient_Request(t *testing.T) {
 				assert.True(t, errors.As(err, &netErr))
 			},
 		},
+		"body within limit": {
+			setupClient:  func() *APIClient { return NewAPIClient(WithMaxResponseBytes(4)) },
+			setupRequest: func() *http.Request { return newTestRequest(http.MethodGet, nil) },
+			transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return newJSONResponse(http.StatusOK, "1234"), nil
+			}),
+			wantBody:   []byte("1234"),
+			wantStatus: http.StatusOK,
+		},
+		"body over limit": {
+			setupClient:  func() *APIClient { return NewAPIClient(WithMaxResponseBytes(4)) },
+			setupRequest: func() *http.Request { return newTestRequest(http.MethodGet, nil) },
+			transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return newJSONResponse(http.StatusOK, "12345"), nil
+			}),
+			wantStatus: http.StatusOK,
+			wantErr:    true,
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrResponseTooLarge)
+			},
+		},
+		"declared length over limit": {
+			setupClient:  func() *APIClient { return NewAPIClient(WithMaxResponseBytes(4)) },
+			setupRequest: func() *http.Request { return newTestRequest(http.MethodGet, nil) },
+			transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				res := newJSONResponse(http.StatusOK, "")
+				res.ContentLength = 1 << 40
+				return res, nil
+			}),
+			wantStatus: http.StatusOK,
+			wantErr:    true,
+			checkErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, ErrResponseTooLarge)
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -140,3 +175,17 @@ type fakeTimeoutError struct{}
 func (fakeTimeoutError) Error() string   { return "timeout" }
 func (fakeTimeoutError) Timeout() bool   { return true }
 func (fakeTimeoutError) Temporary() bool { return true }
+
+func TestAPIClient_RequestPrefix(t *testing.T) {
+	client := NewAPIClient(WithMaxResponseBytes(2))
+	client.Client.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		res := newJSONResponse(http.StatusOK, "12345")
+		res.ContentLength = 5
+		return res, nil
+	})
+	body, statusCode, err := client.RequestPrefix(newTestRequest(http.MethodGet, nil), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("123"), body)
+	assert.Equal(t, http.StatusOK, statusCode)
+}
@@ -0,0 +1,198 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrSSRFBlocked is returned when a request targets a destination that the
+// SSRF policy does not allow.
+var ErrSSRFBlocked = errors.New("destination blocked by SSRF policy")
+
+const maxRedirects = 10
+
+// blockedPrefixes are the address ranges rejected by default: loopback,
+// link-local (including cloud metadata endpoints), private, multicast,
+// documentation, benchmarking, discard-only and the other ranges of the IANA
+// IPv4 and IPv6 special-purpose address registries. NAT64, 6to4 and Teredo are blocked as a whole
+// since their addresses embed an IPv4 destination that may be private.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/32"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// SSRFPolicy restricts the destinations an APIClient may connect to.
+// Addresses are validated at connect time, after DNS resolution, so every
+// redirect hop and every resolved IP is checked.
+type SSRFPolicy struct {
+	// AllowCIDRs are permitted even when they fall in a blocked range.
+	AllowCIDRs []netip.Prefix
+	// DenyCIDRs are blocked in addition to the default ranges.
+	DenyCIDRs []netip.Prefix
+	// AllowedPorts defaults to 80 and 443 when empty.
+	AllowedPorts []int
+	// AllowedSchemes defaults to http and https when empty.
+	AllowedSchemes []string
+}
+
+// WithSSRFProtection makes the client refuse connections that the policy does
+// not allow. A nil policy uses the defaults.
+func WithSSRFProtection(policy *SSRFPolicy) APIClientOption {
+	return func(c *APIClient) {
+		if policy == nil {
+			policy = &SSRFPolicy{}
+		}
+		c.ssrfPolicy = policy
+	}
+}
+
+// ParseCIDRs parses CIDR notations or bare IP addresses into prefixes.
+func ParseCIDRs(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.Contains(v, "/") {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", v, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", v, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// CheckURL validates the scheme and port of u.
+func (p *SSRFPolicy) CheckURL(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if !slices.Contains(p.schemes(), scheme) {
+		return fmt.Errorf("%w: scheme %q is not allowed", ErrSSRFBlocked, u.Scheme)
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort(scheme)
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || !slices.Contains(p.ports(), n) {
+		return fmt.Errorf("%w: port %s is not allowed", ErrSSRFBlocked, port)
+	}
+	return nil
+}
+
+// CheckAddr validates a resolved IP address.
+func (p *SSRFPolicy) CheckAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if containsAddr(p.AllowCIDRs, addr) {
+		return nil
+	}
+	if containsAddr(blockedPrefixes, addr) || containsAddr(p.DenyCIDRs, addr) {
+		return fmt.Errorf("%w: address %s is not allowed", ErrSSRFBlocked, addr)
+	}
+	return nil
+}
+
+// control is a net.Dialer Control hook validating the resolved destination
+// right before the connection is made.
+func (p *SSRFPolicy) control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: invalid address %q", ErrSSRFBlocked, address)
+	}
+	if !slices.Contains(p.ports(), int(addrPort.Port())) {
+		return fmt.Errorf("%w: port %d is not allowed", ErrSSRFBlocked, addrPort.Port())
+	}
+	return p.CheckAddr(addrPort.Addr())
+}
+
+func (p *SSRFPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return p.CheckURL(req.URL)
+}
+
+// apply configures the client to enforce the policy. Proxies are disabled, as
+// the dialer would otherwise validate the proxy instead of the destination.
+func (p *SSRFPolicy) apply(client *http.Client) {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   p.control,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	client.Transport = transport
+	client.CheckRedirect = p.checkRedirect
+}
+
+func (p *SSRFPolicy) ports() []int {
+	if len(p.AllowedPorts) == 0 {
+		return []int{80, 443}
+	}
+	return p.AllowedPorts
+}
+
+func (p *SSRFPolicy) schemes() []string {
+	if len(p.AllowedSchemes) == 0 {
+		return []string{"http", "https"}
+	}
+	return p.AllowedSchemes
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultPort(scheme string) string {
+	if scheme == "https" {
+		return "443"
+	}
+	return "80"
+}
@@ -0,0 +1,172 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSRFPolicy_CheckAddr(t *testing.T) {
+	tests := map[string]struct {
+		policy  *SSRFPolicy
+		addr    string
+		wantErr bool
+	}{
+		"public IPv4":           {policy: &SSRFPolicy{}, addr: "93.184.216.34"},
+		"public IPv6":           {policy: &SSRFPolicy{}, addr: "2606:2800:220:1::1"},
+		"loopback":              {policy: &SSRFPolicy{}, addr: "127.0.0.1", wantErr: true},
+		"IPv6 loopback":         {policy: &SSRFPolicy{}, addr: "::1", wantErr: true},
+		"metadata endpoint":     {policy: &SSRFPolicy{}, addr: "169.254.169.254", wantErr: true},
+		"NAT64":                 {policy: &SSRFPolicy{}, addr: "64:ff9b::a9fe:a9fe", wantErr: true},
+		"6to4":                  {policy: &SSRFPolicy{}, addr: "2002:7f00:1::1", wantErr: true},
+		"Teredo":                {policy: &SSRFPolicy{}, addr: "2001:0:4136:e378:8000:63bf:3fff:fdd2", wantErr: true},
+		"TEST-NET-1":            {policy: &SSRFPolicy{}, addr: "192.0.2.1", wantErr: true},
+		"TEST-NET-2":            {policy: &SSRFPolicy{}, addr: "198.51.100.1", wantErr: true},
+		"TEST-NET-3":            {policy: &SSRFPolicy{}, addr: "203.0.113.1", wantErr: true},
+		"discard-only":          {policy: &SSRFPolicy{}, addr: "100::1", wantErr: true},
+		"IPv6 documentation":    {policy: &SSRFPolicy{}, addr: "2001:db8::1", wantErr: true},
+		"RFC1918":               {policy: &SSRFPolicy{}, addr: "10.1.2.3", wantErr: true},
+		"RFC1918 172.16/12":     {policy: &SSRFPolicy{}, addr: "172.20.0.1", wantErr: true},
+		"multicast":             {policy: &SSRFPolicy{}, addr: "224.0.0.1", wantErr: true},
+		"IPv4-mapped loopback":  {policy: &SSRFPolicy{}, addr: "::ffff:127.0.0.1", wantErr: true},
+		"unique local IPv6":     {policy: &SSRFPolicy{}, addr: "fd00::1", wantErr: true},
+		"allow overrides block": {policy: &SSRFPolicy{AllowCIDRs: mustParseCIDRs(t, "10.0.0.0/24")}, addr: "10.0.0.5"},
+		"deny public range":     {policy: &SSRFPolicy{DenyCIDRs: mustParseCIDRs(t, "93.184.216.34")}, addr: "93.184.216.34", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.policy.CheckAddr(netip.MustParseAddr(tc.addr))
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrSSRFBlocked)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSSRFPolicy_CheckURL(t *testing.T) {
+	tests := map[string]struct {
+		policy  *SSRFPolicy
+		url     string
+		wantErr bool
+	}{
+		"https default port":   {policy: &SSRFPolicy{}, url: "https://example.com/"},
+		"http explicit port":   {policy: &SSRFPolicy{}, url: "http://example.com:80/"},
+		"non standard port":    {policy: &SSRFPolicy{}, url: "http://example.com:8080/", wantErr: true},
+		"file scheme":          {policy: &SSRFPolicy{}, url: "file:///etc/passwd", wantErr: true},
+		"gopher scheme":        {policy: &SSRFPolicy{}, url: "gopher://example.com/", wantErr: true},
+		"configured port":      {policy: &SSRFPolicy{AllowedPorts: []int{8080}}, url: "http://example.com:8080/"},
+		"configured schemes":   {policy: &SSRFPolicy{AllowedSchemes: []string{"https"}}, url: "http://example.com/", wantErr: true},
+		"uppercase scheme":     {policy: &SSRFPolicy{}, url: "HTTPS://example.com/"},
+		"invalid port literal": {policy: &SSRFPolicy{}, url: "http://example.com:abc/", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			if err != nil {
+				// url.Parse rejects some malformed ports itself
+				require.True(t, tc.wantErr)
+				return
+			}
+			err = tc.policy.CheckURL(u)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrSSRFBlocked)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAPIClient_Request_SSRFProtection(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("internal"))
+	}))
+	defer target.Close()
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	}))
+	defer redirector.Close()
+
+	targetPort := serverPort(t, target)
+	redirectorPort := serverPort(t, redirector)
+
+	t.Run("blocks loopback at connect time", func(t *testing.T) {
+		client := NewAPIClient(WithSSRFProtection(&SSRFPolicy{AllowedPorts: []int{targetPort}}))
+		req, _ := http.NewRequest(http.MethodGet, target.URL, nil)
+		body, status, err := client.Request(req)
+		assert.ErrorIs(t, err, ErrSSRFBlocked)
+		assert.Nil(t, body)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("allows configured CIDR", func(t *testing.T) {
+		client := NewAPIClient(WithSSRFProtection(&SSRFPolicy{
+			AllowCIDRs:   mustParseCIDRs(t, "127.0.0.0/8"),
+			AllowedPorts: []int{targetPort},
+		}))
+		req, _ := http.NewRequest(http.MethodGet, target.URL, nil)
+		body, status, err := client.Request(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "internal", string(body))
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("re-checks redirect hops", func(t *testing.T) {
+		client := NewAPIClient(WithSSRFProtection(&SSRFPolicy{
+			AllowCIDRs:   mustParseCIDRs(t, "127.0.0.0/8"),
+			AllowedPorts: []int{redirectorPort},
+		}))
+		req, _ := http.NewRequest(http.MethodGet, redirector.URL, nil)
+		_, status, err := client.Request(req)
+		assert.True(t, errors.Is(err, ErrSSRFBlocked), "got %v", err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("rejects disallowed scheme before connecting", func(t *testing.T) {
+		client := NewAPIClient(WithSSRFProtection(nil))
+		req, _ := http.NewRequest(http.MethodGet, "ftp://example.com/file", nil)
+		_, status, err := client.Request(req)
+		assert.ErrorIs(t, err, ErrSSRFBlocked)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+}
+
+func TestParseCIDRs(t *testing.T) {
+	prefixes, err := ParseCIDRs([]string{"10.0.0.0/8", "192.168.1.10", " ", "fd00::/8"})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.10/32"),
+		netip.MustParsePrefix("fd00::/8"),
+	}, prefixes)
+
+	_, err = ParseCIDRs([]string{"not-a-cidr"})
+	assert.Error(t, err)
+}
+
+func mustParseCIDRs(t *testing.T, values ...string) []netip.Prefix {
+	t.Helper()
+	prefixes, err := ParseCIDRs(values)
+	require.NoError(t, err)
+	return prefixes
+}
+
+func serverPort(t *testing.T, srv *httptest.Server) int {
+	t.Helper()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+	return port
+}
@@ -0,0 +1,234 @@
+// Package archive downloads the preview images of fetched results so they can
+// be served locally instead of hotlinked.
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tro3373/ogp/pkg/imageutil"
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+const maxDownloadBytes = 20 << 20
+
+var extensions = map[string]string{
+	"image/jpeg":               ".jpg",
+	"image/png":                ".png",
+	"image/gif":                ".gif",
+	"image/webp":               ".webp",
+	"image/bmp":                ".bmp",
+	"image/x-icon":             ".ico",
+	"image/vnd.microsoft.icon": ".ico",
+	"image/svg+xml":            ".svg",
+}
+
+// Saver downloads result images into a directory, naming files by the
+// SHA-256 of their content so identical images are stored once.
+type Saver struct {
+	client        ogp.HTTPClient
+	dir           string
+	saveFavicon   bool
+	thumbnailSize int
+}
+
+// Option applies a configuration to a Saver.
+type Option func(*Saver)
+
+// WithFavicon controls whether the site icon is saved as well.
+func WithFavicon(enabled bool) Option {
+	return func(s *Saver) { s.saveFavicon = enabled }
+}
+
+// WithThumbnailSize sets the bounding box of generated thumbnails in pixels.
+// Thumbnails are not generated when size is 0.
+func WithThumbnailSize(size int) Option {
+	return func(s *Saver) { s.thumbnailSize = size }
+}
+
+// New creates a Saver writing into dir.
+func New(client ogp.HTTPClient, dir string, opts ...Option) *Saver {
+	s := &Saver{client: client, dir: dir}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Save downloads the preview image (and favicon, when enabled) of a result and
+// records the local copies on it.
+func (s *Saver) Save(result *ogp.Result) error {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+
+	var errs []error
+	if result.Image != "" {
+		local, err := s.download(result.Image, s.thumbnailSize)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to save image %s: %w", result.Image, err))
+		}
+		result.LocalImage = local
+	}
+	if s.saveFavicon {
+		if iconURL := faviconURL(result); iconURL != "" {
+			local, err := s.download(iconURL, 0)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to save favicon %s: %w", iconURL, err))
+			}
+			result.LocalIcon = local
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Saver) download(fileURL string, thumbnailSize int) (*ogp.LocalFile, error) {
+	req, err := http.NewRequest(http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+
+	body, statusCode, err := s.request(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch: %w", err)
+	}
+	if statusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("HTTP %d", statusCode)
+	}
+	if len(body) > maxDownloadBytes {
+		return nil, fmt.Errorf("too large (over %d bytes)", maxDownloadBytes)
+	}
+	mimeType, ok := imageutil.Sniff(body)
+	if !ok && isSVG(body) {
+		mimeType, ok = "image/svg+xml", true
+	}
+	if !ok {
+		return nil, fmt.Errorf("not an image: %s", mimeType)
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+	path := filepath.Join(s.dir, hash+extension(mimeType))
+	if err := writeFile(path, body); err != nil {
+		return nil, err
+	}
+
+	local := &ogp.LocalFile{
+		Path:   path,
+		Size:   int64(len(body)),
+		SHA256: hash,
+		Type:   mimeType,
+	}
+	// SVG images are saved as is; they cannot be decoded for thumbnails.
+	if thumbnailSize > 0 && mimeType != "image/svg+xml" {
+		thumb, err := s.thumbnail(body, hash, thumbnailSize)
+		if err != nil {
+			return local, fmt.Errorf("failed to create thumbnail: %w", err)
+		}
+		local.Thumbnail = thumb
+	}
+	return local, nil
+}
+
+// request sends req, reading at most one byte more than maxDownloadBytes when
+// the client can stop early so oversized images are rejected without being
+// read in full.
+func (s *Saver) request(req *http.Request) ([]byte, int, error) {
+	if c, ok := s.client.(ogp.PrefixHTTPClient); ok {
+		return c.RequestPrefix(req, maxDownloadBytes+1)
+	}
+	return s.client.Request(req)
+}
+
+// isSVG reports whether data is an SVG document: an <svg> root element,
+// optionally preceded by an XML declaration, comments or a doctype.
+func isSVG(data []byte) bool {
+	rest := strings.TrimSpace(string(data[:min(len(data), 1024)]))
+	for {
+		switch {
+		case strings.HasPrefix(rest, "<?"):
+			rest = skipPast(rest, "?>")
+		case strings.HasPrefix(rest, "<!--"):
+			rest = skipPast(rest, "-->")
+		case strings.HasPrefix(rest, "<!"):
+			rest = skipPast(rest, ">")
+		default:
+			return strings.HasPrefix(rest, "<svg") && len(rest) > 4 &&
+				strings.ContainsRune(" \t\r\n/>", rune(rest[4]))
+		}
+	}
+}
+
+// skipPast returns s after the first occurrence of sep, with leading space
+// trimmed, or "" when sep does not occur.
+func skipPast(s, sep string) string {
+	_, after, ok := strings.Cut(s, sep)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(after)
+}
+
+// thumbnail writes a copy of the image scaled to fit within size x size.
+// PNG and GIF sources keep transparency as PNG, others are encoded as JPEG.
+func (s *Saver) thumbnail(data []byte, hash string, size int) (string, error) {
+	img, srcFormat, err := imageutil.Decode(data)
+	if err != nil {
+		return "", err
+	}
+	format := imageutil.FormatJPEG
+	if srcFormat == "png" || srcFormat == "gif" {
+		format = imageutil.FormatPNG
+	}
+
+	var buf bytes.Buffer
+	if err := imageutil.Encode(&buf, imageutil.Resize(img, size, size), format); err != nil {
+		return "", err
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("%s_%d%s", hash, size, extension(imageutil.ContentType(format))))
+	if err := writeFile(path, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// writeFile writes data unless a file with the same content-addressed name
+// already exists.
+func writeFile(path string, data []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func extension(mimeType string) string {
+	if ext, ok := extensions[mimeType]; ok {
+		return ext
+	}
+	return ".img"
+}
+
+// faviconURL returns the icon of the result, or the conventional
+// /favicon.ico of its site.
+func faviconURL(result *ogp.Result) string {
+	if result.Icon != "" {
+		return result.Icon
+	}
+	u, err := url.Parse(result.URL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/favicon.ico"}).String()
+}
@@ -0,0 +1,203 @@
+package archive
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+type fakeHTTPClient struct {
+	handler func(req *http.Request) ([]byte, int, error)
+}
+
+func (c *fakeHTTPClient) Request(req *http.Request) ([]byte, int, error) {
+	return c.handler(req)
+}
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+const testSVG = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- logo -->
+<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`
+
+// prefixHTTPClient records the limit its body reads were capped at.
+type prefixHTTPClient struct {
+	fakeHTTPClient
+	limit int64
+}
+
+func (c *prefixHTTPClient) RequestPrefix(req *http.Request, n int64) ([]byte, int, error) {
+	c.limit = n
+	body, statusCode, err := c.handler(req)
+	if int64(len(body)) > n {
+		body = body[:n]
+	}
+	return body, statusCode, err
+}
+
+func newTestClient(t *testing.T) *fakeHTTPClient {
+	t.Helper()
+	img := testPNG(t, 400, 200)
+	icon := testPNG(t, 16, 16)
+	return &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch req.URL.Path {
+			case "/img.png", "/same.png":
+				return img, http.StatusOK, nil
+			case "/favicon.ico":
+				return icon, http.StatusOK, nil
+			case "/logo.svg":
+				return []byte(testSVG), http.StatusOK, nil
+			case "/page.html":
+				return []byte("<html></html>"), http.StatusOK, nil
+			}
+			return []byte("Not Found"), http.StatusNotFound, nil
+		},
+	}
+}
+
+func TestSaver_Save(t *testing.T) {
+	dir := t.TempDir()
+	saver := New(newTestClient(t), dir, WithFavicon(true), WithThumbnailSize(100))
+	result := &ogp.Result{URL: "https://example.com/post", Image: "https://example.com/img.png"}
+
+	if err := saver.Save(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	local := result.LocalImage
+	if local == nil {
+		t.Fatal("expected local image")
+	}
+	if filepath.Dir(local.Path) != dir || filepath.Base(local.Path) != local.SHA256+".png" {
+		t.Errorf("unexpected path %q for hash %q", local.Path, local.SHA256)
+	}
+	if local.Type != "image/png" || local.Size == 0 {
+		t.Errorf("unexpected local image: %+v", local)
+	}
+	if fi, err := os.Stat(local.Path); err != nil || fi.Size() != local.Size {
+		t.Errorf("saved file mismatch: %v", err)
+	}
+
+	f, err := os.Open(local.Thumbnail)
+	if err != nil {
+		t.Fatalf("missing thumbnail: %v", err)
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil || cfg.Width != 100 || cfg.Height != 50 {
+		t.Errorf("got thumbnail %dx%d (%v), want 100x50", cfg.Width, cfg.Height, err)
+	}
+
+	if result.LocalIcon == nil || result.LocalIcon.Thumbnail != "" {
+		t.Errorf("unexpected local icon: %+v", result.LocalIcon)
+	}
+}
+
+func TestSaver_Save_DeduplicatesByContent(t *testing.T) {
+	dir := t.TempDir()
+	saver := New(newTestClient(t), dir)
+	a := &ogp.Result{URL: "https://a.example.com", Image: "https://a.example.com/img.png"}
+	b := &ogp.Result{URL: "https://b.example.com", Image: "https://b.example.com/same.png"}
+
+	for _, r := range []*ogp.Result{a, b} {
+		if err := saver.Save(r); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if a.LocalImage.Path != b.LocalImage.Path {
+		t.Errorf("got paths %q and %q, want equal", a.LocalImage.Path, b.LocalImage.Path)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("got %d files, want 1", len(entries))
+	}
+}
+
+func TestSaver_Save_Errors(t *testing.T) {
+	tests := map[string]string{
+		"not an image": "https://example.com/page.html",
+		"missing":      "https://example.com/missing.png",
+	}
+
+	for name, imageURL := range tests {
+		t.Run(name, func(t *testing.T) {
+			saver := New(newTestClient(t), t.TempDir())
+			result := &ogp.Result{URL: "https://example.com", Image: imageURL}
+			if err := saver.Save(result); err == nil {
+				t.Error("expected error, got nil")
+			}
+			if result.LocalImage != nil {
+				t.Errorf("unexpected local image: %+v", result.LocalImage)
+			}
+		})
+	}
+}
+
+func TestSaver_Save_SVG(t *testing.T) {
+	saver := New(newTestClient(t), t.TempDir(), WithThumbnailSize(100))
+	result := &ogp.Result{URL: "https://example.com", Image: "https://example.com/logo.svg"}
+
+	if err := saver.Save(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	local := result.LocalImage
+	if local == nil || local.Type != "image/svg+xml" || filepath.Ext(local.Path) != ".svg" || local.Thumbnail != "" {
+		t.Errorf("unexpected local image: %+v", local)
+	}
+}
+
+func TestSaver_Save_TooLarge(t *testing.T) {
+	large := append(testPNG(t, 1, 1), make([]byte, maxDownloadBytes)...)
+	client := &prefixHTTPClient{fakeHTTPClient: fakeHTTPClient{
+		handler: func(*http.Request) ([]byte, int, error) { return large, http.StatusOK, nil },
+	}}
+	saver := New(client, t.TempDir())
+	result := &ogp.Result{URL: "https://example.com", Image: "https://example.com/large.png"}
+
+	if err := saver.Save(result); err == nil {
+		t.Error("expected error, got nil")
+	}
+	if client.limit != maxDownloadBytes+1 {
+		t.Errorf("got read limit %d, want %d", client.limit, maxDownloadBytes+1)
+	}
+	if result.LocalImage != nil {
+		t.Errorf("unexpected local image: %+v", result.LocalImage)
+	}
+}
+
+func TestIsSVG(t *testing.T) {
+	tests := map[string]struct {
+		data string
+		want bool
+	}{
+		"bare root":    {`<svg viewBox="0 0 1 1"/>`, true},
+		"with prolog":  {testSVG, true},
+		"with doctype": {"<!DOCTYPE svg>\n<svg>", true},
+		"html":         {"<html><svg></svg></html>", false},
+		"similar name": {"<svgfoo/>", false},
+		"plain text":   {"svg", false},
+		"unterminated": {"<!-- <svg>", false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isSVG([]byte(tt.data)); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package format
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+const (
+	atomNamespace = "http://www.w3.org/2005/Atom"
+	// atomDefaultID is used as the required feed id when no link is configured.
+	atomDefaultID = "urn:ogp:feed"
+)
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"feed"`
+	Namespace string      `xml:"xmlns,attr"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Generator string      `xml:"generator"`
+	Author    atomPerson  `xml:"author"`
+	Links     []atomLink  `xml:"link"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published,omitempty"`
+	Summary   string     `xml:"summary,omitempty"`
+	Links     []atomLink `xml:"link"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// Atom renders results as an Atom 1.0 document.
+type Atom struct {
+	Feed Feed
+}
+
+// Format writes results as an Atom feed.
+func (a *Atom) Format(w io.Writer, results []*ogp.Result) error {
+	updated := a.Feed.updated().UTC().Format(time.RFC3339)
+	doc := atomFeed{
+		Namespace: atomNamespace,
+		ID:        a.Feed.Link,
+		Title:     a.Feed.Title,
+		Subtitle:  a.Feed.Description,
+		Updated:   updated,
+		Generator: feedGenerator,
+		Author:    atomPerson{Name: a.Feed.author()},
+	}
+	if doc.ID == "" {
+		doc.ID = atomDefaultID
+	}
+	if a.Feed.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Href: a.Feed.Link, Rel: "alternate"})
+	}
+	for _, r := range results {
+		doc.Entries = append(doc.Entries, newAtomEntry(r, updated))
+	}
+	return writeXML(w, doc)
+}
+
+func newAtomEntry(r *ogp.Result, feedUpdated string) atomEntry {
+	entry := atomEntry{
+		ID:      r.URL,
+		Title:   cardTitle(r),
+		Updated: feedUpdated,
+		Summary: r.Description,
+		Links:   []atomLink{{Href: r.URL, Rel: "alternate"}},
+	}
+	if t, ok := publishedTime(r); ok {
+		entry.Published = t.Format(time.RFC3339)
+		entry.Updated = entry.Published
+	}
+	if r.Image != "" {
+		entry.Links = append(entry.Links, atomLink{Href: r.Image, Rel: "enclosure", Type: imageMIMEType(r.Image)})
+	}
+	return entry
+}
@@ -0,0 +1,138 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// ChatFormatter is implemented by formatters whose output can be posted to a
+// chat incoming webhook as one or more JSON messages.
+type ChatFormatter interface {
+	Formatter
+	Payloads(results []*ogp.Result) ([]any, error)
+}
+
+// cardPalette is used to pick a stable accent color per site when no color is
+// configured, so cards from the same site share a color.
+var cardPalette = []int{
+	0x5865F2, // blurple
+	0x2EB67D, // green
+	0xE01E5A, // red
+	0xECB22E, // yellow
+	0x36C5F0, // blue
+	0x9B59B6, // purple
+	0xE67E22, // orange
+	0x1ABC9C, // teal
+}
+
+// PostWebhook posts each payload as a JSON message to an incoming webhook URL.
+func PostWebhook(client ogp.HTTPClient, webhookURL string, payloads []any) error {
+	for i, payload := range payloads {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		}
+		req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(b))
+		if err != nil {
+			return fmt.Errorf("failed to create webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		body, statusCode, err := client.Request(req)
+		if err != nil {
+			return fmt.Errorf("failed to post webhook message %d/%d: %w", i+1, len(payloads), err)
+		}
+		if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+			return fmt.Errorf("webhook returned status %d: %s", statusCode, strings.TrimSpace(string(body)))
+		}
+	}
+	return nil
+}
+
+func writePayloads(w io.Writer, payloads []any) error {
+	var target any = payloads
+	if len(payloads) == 1 {
+		target = payloads[0]
+	}
+	output, err := json.MarshalIndent(target, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(output)); err != nil {
+		return fmt.Errorf("failed to write payload: %w", err)
+	}
+	return nil
+}
+
+// truncate shortens s to at most limit runes, ending with an ellipsis when cut.
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	if limit <= 1 {
+		return string(runes[:limit])
+	}
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
+
+func runeLen(s string) int {
+	return len([]rune(s))
+}
+
+// isWebURL reports whether s is an absolute http(s) URL, which chat platforms
+// require for links and images.
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func hostOf(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// siteName returns the site name to show on a card, falling back to the host.
+func siteName(r *ogp.Result) string {
+	if r.SiteName != "" {
+		return r.SiteName
+	}
+	return hostOf(r.URL)
+}
+
+func cardTitle(r *ogp.Result) string {
+	if r.Title != "" {
+		return r.Title
+	}
+	return r.URL
+}
+
+// ParseColor parses a "#RRGGBB" or "RRGGBB" hex color into an integer.
+func ParseColor(s string) (int, error) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || v > 0xFFFFFF {
+		return 0, fmt.Errorf("invalid color %q: want #RRGGBB", s)
+	}
+	return int(v), nil
+}
+
+func siteColor(r *ogp.Result) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(siteName(r))))
+	return cardPalette[h.Sum32()%uint32(len(cardPalette))]
+}
@@ -0,0 +1,73 @@
+package format
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+type fakeHTTPClient struct {
+	handler func(req *http.Request) ([]byte, int, error)
+}
+
+func (c *fakeHTTPClient) Request(req *http.Request) ([]byte, int, error) {
+	return c.handler(req)
+}
+
+func TestPostWebhook(t *testing.T) {
+	var bodies []string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.Method != http.MethodPost {
+				t.Errorf("got method %s, want POST", req.Method)
+			}
+			if got := req.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("got content type %q", got)
+			}
+			b, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(b))
+			return []byte("ok"), http.StatusOK, nil
+		},
+	}
+
+	payloads := []any{map[string]string{"text": "a"}, map[string]string{"text": "b"}}
+	if err := PostWebhook(client, "https://hooks.example.com/x", payloads); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != `{"text":"a"}` {
+		t.Errorf("unexpected bodies: %v", bodies)
+	}
+}
+
+func TestPostWebhook_ErrorStatus(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			return []byte("invalid_payload"), http.StatusBadRequest, nil
+		},
+	}
+
+	err := PostWebhook(client, "https://hooks.example.com/x", []any{map[string]string{}})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := map[string]struct {
+		s     string
+		limit int
+		want  string
+	}{
+		"short string unchanged": {s: "abc", limit: 5, want: "abc"},
+		"cut with ellipsis":      {s: "abcdef", limit: 4, want: "abc…"},
+		"counts runes":           {s: "あいうえお", limit: 3, want: "あい…"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := truncate(tc.s, tc.limit); got != tc.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tc.s, tc.limit, got, tc.want)
+			}
+		})
+	}
+}
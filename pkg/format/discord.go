@@ -0,0 +1,133 @@
+package format
+
+import (
+	"io"
+	"strings"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// Discord embed limits.
+// ref: https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	discordMaxEmbeds      = 10
+	discordMaxTotalChars  = 6000
+	discordMaxTitle       = 256
+	discordMaxDescription = 4096
+	discordMaxAuthorName  = 256
+	discordMaxFooterText  = 2048
+)
+
+// DiscordMessage is a Discord webhook message carrying embeds.
+type DiscordMessage struct {
+	Embeds []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a Discord embed object.
+type DiscordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Color       int            `json:"color"`
+	Author      *DiscordAuthor `json:"author,omitempty"`
+	Image       *DiscordImage  `json:"image,omitempty"`
+	Footer      *DiscordFooter `json:"footer,omitempty"`
+}
+
+// DiscordAuthor is the author of an embed.
+type DiscordAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// DiscordImage is an embed image or thumbnail.
+type DiscordImage struct {
+	URL string `json:"url"`
+}
+
+// DiscordFooter is the footer of an embed.
+type DiscordFooter struct {
+	Text string `json:"text"`
+}
+
+// Discord renders results as Discord embeds. The site name is shown as the
+// embed author and the embed color is stable per site unless Color is set.
+type Discord struct {
+	// Color overrides the per-site embed color when set.
+	Color *int
+}
+
+// Format writes the Discord messages as JSON.
+func (d *Discord) Format(w io.Writer, results []*ogp.Result) error {
+	payloads, err := d.Payloads(results)
+	if err != nil {
+		return err
+	}
+	return writePayloads(w, payloads)
+}
+
+// Payloads builds Discord messages, splitting results so that no message
+// exceeds the embed count or total character limits.
+func (d *Discord) Payloads(results []*ogp.Result) ([]any, error) {
+	var (
+		payloads []any
+		msg      *DiscordMessage
+		chars    int
+	)
+	for _, r := range results {
+		embed := d.embed(r)
+		n := embedChars(embed)
+		if msg != nil && (len(msg.Embeds) >= discordMaxEmbeds || chars+n > discordMaxTotalChars) {
+			payloads = append(payloads, msg)
+			msg = nil
+		}
+		if msg == nil {
+			msg, chars = &DiscordMessage{}, 0
+		}
+		msg.Embeds = append(msg.Embeds, embed)
+		chars += n
+	}
+	if msg != nil {
+		payloads = append(payloads, msg)
+	}
+	return payloads, nil
+}
+
+func (d *Discord) embed(r *ogp.Result) DiscordEmbed {
+	embed := DiscordEmbed{
+		Title:       truncate(cardTitle(r), discordMaxTitle),
+		Description: truncate(strings.TrimSpace(r.Description), discordMaxDescription),
+		Color:       siteColor(r),
+	}
+	if d.Color != nil {
+		embed.Color = *d.Color
+	}
+	if isWebURL(r.URL) {
+		embed.URL = r.URL
+	}
+	if name := siteName(r); name != "" {
+		embed.Author = &DiscordAuthor{Name: truncate(name, discordMaxAuthorName)}
+	}
+	if isWebURL(r.Image) {
+		embed.Image = &DiscordImage{URL: r.Image}
+	}
+	if host := hostOf(r.URL); host != "" {
+		embed.Footer = &DiscordFooter{Text: truncate(host, discordMaxFooterText)}
+	}
+	if over := embedChars(embed) - discordMaxTotalChars; over > 0 {
+		embed.Description = truncate(embed.Description, max(runeLen(embed.Description)-over, 0))
+	}
+	return embed
+}
+
+// embedChars counts the characters Discord includes in the total embed limit.
+func embedChars(e DiscordEmbed) int {
+	n := runeLen(e.Title) + runeLen(e.Description)
+	if e.Author != nil {
+		n += runeLen(e.Author.Name)
+	}
+	if e.Footer != nil {
+		n += runeLen(e.Footer.Text)
+	}
+	return n
+}
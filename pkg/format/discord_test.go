@@ -0,0 +1,120 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+func TestDiscord_Payloads_Embed(t *testing.T) {
+	results := []*ogp.Result{{
+		URL:         "https://example.com/page",
+		Title:       strings.Repeat("t", 300),
+		Description: "Description",
+		Image:       "https://example.com/img.png",
+		SiteName:    "Example",
+	}}
+
+	color := 0x123456
+	payloads, err := (&Discord{Color: &color}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	embed := payloads[0].(*DiscordMessage).Embeds[0]
+
+	if n := runeLen(embed.Title); n != discordMaxTitle {
+		t.Errorf("got title length %d, want %d", n, discordMaxTitle)
+	}
+	if embed.URL != "https://example.com/page" {
+		t.Errorf("got url %q", embed.URL)
+	}
+	if embed.Color != 0x123456 {
+		t.Errorf("got color %#x, want %#x", embed.Color, 0x123456)
+	}
+	if embed.Author == nil || embed.Author.Name != "Example" {
+		t.Errorf("unexpected author: %+v", embed.Author)
+	}
+	if embed.Image == nil || embed.Image.URL != "https://example.com/img.png" {
+		t.Errorf("unexpected image: %+v", embed.Image)
+	}
+}
+
+func TestDiscord_Payloads_SiteColorIsStable(t *testing.T) {
+	results := []*ogp.Result{
+		{URL: "https://example.com/a", SiteName: "Example"},
+		{URL: "https://example.com/b", SiteName: "Example"},
+	}
+
+	payloads, err := (&Discord{}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	embeds := payloads[0].(*DiscordMessage).Embeds
+	if embeds[0].Color == 0 || embeds[0].Color != embeds[1].Color {
+		t.Errorf("got colors %#x and %#x, want equal non-zero", embeds[0].Color, embeds[1].Color)
+	}
+}
+
+func TestDiscord_Payloads_SplitsMessages(t *testing.T) {
+	var results []*ogp.Result
+	for i := range 12 {
+		results = append(results, &ogp.Result{
+			URL:         fmt.Sprintf("https://example.com/%d", i),
+			Description: strings.Repeat("d", 1000),
+		})
+	}
+
+	payloads, err := (&Discord{}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	total := 0
+	for _, p := range payloads {
+		msg := p.(*DiscordMessage)
+		if len(msg.Embeds) > discordMaxEmbeds {
+			t.Errorf("message has %d embeds, want <= %d", len(msg.Embeds), discordMaxEmbeds)
+		}
+		chars := 0
+		for _, e := range msg.Embeds {
+			chars += embedChars(e)
+		}
+		if chars > discordMaxTotalChars {
+			t.Errorf("message has %d chars, want <= %d", chars, discordMaxTotalChars)
+		}
+		total += len(msg.Embeds)
+	}
+	if total != len(results) {
+		t.Errorf("got %d embeds, want %d", total, len(results))
+	}
+}
+
+func TestDiscord_Payloads_CapsEmbedTotal(t *testing.T) {
+	results := []*ogp.Result{{
+		URL:         "https://" + strings.Repeat("h", 2100) + ".example.com/",
+		Title:       strings.Repeat("t", 300),
+		Description: strings.Repeat("d", 5000),
+		SiteName:    strings.Repeat("s", 300),
+	}}
+
+	payloads, err := (&Discord{}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	embed := payloads[0].(*DiscordMessage).Embeds[0]
+	if n := embedChars(embed); n > discordMaxTotalChars {
+		t.Errorf("embed has %d chars, want <= %d", n, discordMaxTotalChars)
+	}
+}
+
+func TestDiscord_Payloads_BlackColor(t *testing.T) {
+	black := 0
+	payloads, err := (&Discord{Color: &black}).Payloads([]*ogp.Result{{URL: "https://example.com/", Title: "T"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := payloads[0].(*DiscordMessage).Embeds[0].Color; got != 0 {
+		t.Errorf("got color %#x, want black", got)
+	}
+}
@@ -0,0 +1,77 @@
+package format
+
+import (
+	"mime"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// Feed holds the feed-level metadata of RSS and Atom documents.
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	// Author is the feed author. The host of Link is used when empty.
+	Author string
+	// Updated is the feed build time. The current time is used when zero.
+	Updated time.Time
+}
+
+func (f *Feed) updated() time.Time {
+	if f.Updated.IsZero() {
+		return time.Now()
+	}
+	return f.Updated
+}
+
+// link returns the feed link, falling back to the first result URL since
+// RSS requires a channel link.
+func (f *Feed) link(results []*ogp.Result) string {
+	if f.Link != "" {
+		return f.Link
+	}
+	for _, r := range results {
+		if r.URL != "" {
+			return r.URL
+		}
+	}
+	return ""
+}
+
+// author returns the feed author, which Atom requires unless every entry has
+// one.
+func (f *Feed) author() string {
+	if f.Author != "" {
+		return f.Author
+	}
+	if u, err := url.Parse(f.Link); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return feedGenerator
+}
+
+// publishedTime parses the RFC 3339 published date of a result.
+func publishedTime(r *ogp.Result) (time.Time, bool) {
+	if r.Published == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, r.Published)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// imageMIMEType guesses the MIME type of an image URL from its extension.
+func imageMIMEType(imageURL string) string {
+	u, err := url.Parse(imageURL)
+	if err == nil {
+		if t := mime.TypeByExtension(path.Ext(u.Path)); t != "" {
+			return t
+		}
+	}
+	return "image/jpeg"
+}
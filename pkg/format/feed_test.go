@@ -0,0 +1,133 @@
+package format
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+var feedResults = []*ogp.Result{
+	{
+		URL:         "https://example.com/a",
+		Title:       "Article A",
+		Description: "About A & more",
+		Image:       "https://example.com/a.png",
+		Published:   "2024-03-01T09:30:00+09:00",
+	},
+	{URL: "https://example.com/b"},
+}
+
+var feedMeta = Feed{
+	Title:   "Weekly links",
+	Link:    "https://links.example.com/",
+	Updated: time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+}
+
+func TestRSS_Format(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&RSS{Feed: feedMeta}).Format(&buf, feedResults); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc rssDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if doc.Version != "2.0" {
+		t.Errorf("got version %q, want 2.0", doc.Version)
+	}
+	if doc.Channel.Title != "Weekly links" || doc.Channel.Description != "Weekly links" {
+		t.Errorf("unexpected channel: %+v", doc.Channel)
+	}
+	if len(doc.Channel.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(doc.Channel.Items))
+	}
+
+	a := doc.Channel.Items[0]
+	if a.Description != "About A & more" {
+		t.Errorf("got description %q", a.Description)
+	}
+	if a.PubDate != "Fri, 01 Mar 2024 09:30:00 +0900" {
+		t.Errorf("got pubDate %q", a.PubDate)
+	}
+	if a.Enclosure == nil || a.Enclosure.Type != "image/png" {
+		t.Errorf("unexpected enclosure: %+v", a.Enclosure)
+	}
+
+	b := doc.Channel.Items[1]
+	if b.Title != "https://example.com/b" || b.PubDate != "" || b.Enclosure != nil {
+		t.Errorf("unexpected item: %+v", b)
+	}
+}
+
+func TestAtom_Format(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&Atom{Feed: feedMeta}).Format(&buf, feedResults); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`) {
+		t.Errorf("missing Atom namespace: %s", buf.String())
+	}
+
+	var doc atomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if doc.ID != "https://links.example.com/" || doc.Updated != "2024-03-08T00:00:00Z" {
+		t.Errorf("unexpected feed: %+v", doc)
+	}
+	if len(doc.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(doc.Entries))
+	}
+
+	a := doc.Entries[0]
+	if a.Published != "2024-03-01T09:30:00+09:00" || a.Updated != a.Published {
+		t.Errorf("unexpected dates: published=%q updated=%q", a.Published, a.Updated)
+	}
+	if len(a.Links) != 2 || a.Links[1].Rel != "enclosure" {
+		t.Errorf("unexpected links: %+v", a.Links)
+	}
+	if b := doc.Entries[1]; b.Updated != doc.Updated {
+		t.Errorf("got updated %q, want feed updated %q", b.Updated, doc.Updated)
+	}
+}
+
+func TestAtom_Format_DefaultID(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&Atom{Feed: Feed{Title: "T"}}).Format(&buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<id>"+atomDefaultID+"</id>") {
+		t.Errorf("missing default id: %s", buf.String())
+	}
+}
+
+func TestFeed_Defaults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&RSS{Feed: Feed{Title: "T"}}).Format(&buf, feedResults); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rss rssDocument
+	if err := xml.Unmarshal(buf.Bytes(), &rss); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if rss.Channel.Link != "https://example.com/a" {
+		t.Errorf("got channel link %q, want the first item URL", rss.Channel.Link)
+	}
+
+	buf.Reset()
+	if err := (&Atom{Feed: feedMeta}).Format(&buf, feedResults); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var atom atomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &atom); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if atom.Author.Name != "links.example.com" {
+		t.Errorf("got author %q, want the host of the feed link", atom.Author.Name)
+	}
+}
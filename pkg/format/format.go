@@ -0,0 +1,57 @@
+package format
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// Output format names accepted by New.
+const (
+	JSONFormat     = "json"
+	MarkdownFormat = "markdown"
+	HTMLFormat     = "html"
+	SlackFormat    = "slack"
+	DiscordFormat  = "discord"
+)
+
+// Formatter renders fetched results into an output document.
+type Formatter interface {
+	Format(w io.Writer, results []*ogp.Result) error
+}
+
+// Options configures the formatter returned by New.
+type Options struct {
+	// HTMLPage wraps HTML cards in a self-contained page with CSS.
+	HTMLPage bool
+	// Color overrides the per-site accent color of Discord embeds when set;
+	// 0 is black. Slack Block Kit has no accent color, so it is rejected for
+	// Slack.
+	Color *int
+}
+
+// Names returns the supported output format names.
+func Names() []string {
+	return []string{JSONFormat, MarkdownFormat, HTMLFormat, SlackFormat, DiscordFormat}
+}
+
+// New returns the Formatter for the given output format name.
+func New(name string, opts Options) (Formatter, error) {
+	switch name {
+	case "", JSONFormat:
+		return &JSON{}, nil
+	case MarkdownFormat, "md":
+		return &Markdown{}, nil
+	case HTMLFormat:
+		return &HTML{Page: opts.HTMLPage}, nil
+	case SlackFormat:
+		if opts.Color != nil {
+			return nil, fmt.Errorf("output format %q does not support a card color", name)
+		}
+		return &Slack{}, nil
+	case DiscordFormat:
+		return &Discord{Color: opts.Color}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q (available: %v)", name, Names())
+}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// HTML renders each result as an HTML link card. Values are escaped by
+// html/template, so unsafe URLs such as javascript: are neutralized.
+type HTML struct {
+	// Page wraps the cards in a self-contained HTML document with CSS.
+	Page bool
+}
+
+type htmlCard struct {
+	URL         string
+	Title       string
+	Description string
+	Image       string
+	Host        string
+}
+
+type htmlPage struct {
+	Cards []htmlCard
+}
+
+const htmlCardTemplate = `{{define "card"}}<article class="ogp-card">
+{{- if .Image}}
+  <a class="ogp-card-image" href="{{.URL}}"><img src="{{.Image}}" alt="{{.Title}}" loading="lazy"></a>
+{{- end}}
+  <div class="ogp-card-body">
+    <h3 class="ogp-card-title"><a href="{{.URL}}">{{.Title}}</a></h3>
+{{- if .Description}}
+    <blockquote class="ogp-card-description">{{.Description}}</blockquote>
+{{- end}}
+{{- if .Host}}
+    <p class="ogp-card-host">{{.Host}}</p>
+{{- end}}
+  </div>
+</article>
+{{end}}`
+
+const htmlCardsTemplate = `{{range .Cards}}{{template "card" .}}{{end}}`
+
+const htmlPageTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Link previews</title>
+<style>
+body { margin: 0; padding: 24px; background: #f5f6f8; color: #1f2328; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; }
+main { display: grid; gap: 16px; max-width: 720px; margin: 0 auto; }
+.ogp-card { display: flex; overflow: hidden; background: #fff; border: 1px solid #d0d7de; border-radius: 8px; }
+.ogp-card-image { flex: 0 0 200px; }
+.ogp-card-image img { display: block; width: 200px; height: 100%; min-height: 120px; object-fit: cover; }
+.ogp-card-body { flex: 1; min-width: 0; padding: 12px 16px; }
+.ogp-card-title { margin: 0 0 8px; font-size: 16px; }
+.ogp-card-title a { color: inherit; text-decoration: none; }
+.ogp-card-title a:hover { text-decoration: underline; }
+.ogp-card-description { margin: 0 0 8px; color: #59636e; font-size: 14px; overflow: hidden; display: -webkit-box; -webkit-line-clamp: 3; -webkit-box-orient: vertical; }
+.ogp-card-host { margin: 0; color: #818b98; font-size: 12px; }
+</style>
+</head>
+<body>
+<main>
+{{range .Cards}}{{template "card" .}}{{end}}</main>
+</body>
+</html>
+`
+
+var (
+	htmlCardsTmpl = template.Must(template.Must(template.New("cards").Parse(htmlCardTemplate)).Parse(htmlCardsTemplate))
+	htmlPageTmpl  = template.Must(template.Must(template.New("page").Parse(htmlCardTemplate)).Parse(htmlPageTemplate))
+)
+
+// Format writes results as HTML link cards.
+func (h *HTML) Format(w io.Writer, results []*ogp.Result) error {
+	page := htmlPage{Cards: make([]htmlCard, 0, len(results))}
+	for _, r := range results {
+		page.Cards = append(page.Cards, newHTMLCard(r))
+	}
+
+	tmpl := htmlCardsTmpl
+	if h.Page {
+		tmpl = htmlPageTmpl
+	}
+	if err := tmpl.Execute(w, page); err != nil {
+		return fmt.Errorf("failed to render html: %w", err)
+	}
+	return nil
+}
+
+func newHTMLCard(r *ogp.Result) htmlCard {
+	card := htmlCard{
+		URL:         r.URL,
+		Title:       r.Title,
+		Description: r.Description,
+		Image:       r.Image,
+	}
+	if card.Title == "" {
+		card.Title = r.URL
+	}
+	if u, err := url.Parse(r.URL); err == nil {
+		card.Host = u.Hostname()
+	}
+	return card
+}
@@ -0,0 +1,63 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+func TestHTML_Format_EscapesValues(t *testing.T) {
+	results := []*ogp.Result{{
+		URL:         "javascript:alert(1)",
+		Title:       `<script>alert("x")</script>`,
+		Description: "Tom & Jerry",
+		Image:       "https://example.com/img.png",
+	}}
+
+	var buf bytes.Buffer
+	if err := (&HTML{}).Format(&buf, results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+
+	if strings.Contains(got, "<script>") {
+		t.Errorf("title was not escaped: %s", got)
+	}
+	if strings.Contains(got, "javascript:") {
+		t.Errorf("unsafe URL was not neutralized: %s", got)
+	}
+	if !strings.Contains(got, "Tom &amp; Jerry") {
+		t.Errorf("description was not escaped: %s", got)
+	}
+	if strings.Contains(got, "<!DOCTYPE html>") {
+		t.Errorf("unexpected page wrapper: %s", got)
+	}
+}
+
+func TestHTML_Format_Page(t *testing.T) {
+	results := []*ogp.Result{
+		{URL: "https://a.example.com", Title: "A"},
+		{URL: "https://b.example.com", Title: "B", Image: "https://b.example.com/img.png"},
+	}
+
+	var buf bytes.Buffer
+	if err := (&HTML{Page: true}).Format(&buf, results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+
+	if !strings.HasPrefix(got, "<!DOCTYPE html>") {
+		t.Errorf("expected page wrapper, got: %s", got)
+	}
+	if !strings.Contains(got, "<style>") {
+		t.Errorf("expected embedded CSS, got: %s", got)
+	}
+	if n := strings.Count(got, `<article class="ogp-card">`); n != 2 {
+		t.Errorf("got %d cards, want 2", n)
+	}
+	if !strings.Contains(got, `<img src="https://b.example.com/img.png"`) {
+		t.Errorf("expected image, got: %s", got)
+	}
+}
@@ -0,0 +1,30 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// JSON renders results as indented JSON. A single result is rendered as an
+// object, multiple results as an array.
+type JSON struct{}
+
+// Format writes results as JSON.
+func (j *JSON) Format(w io.Writer, results []*ogp.Result) error {
+	var target any = results
+	if len(results) == 1 {
+		target = results[0]
+	}
+
+	output, err := json.MarshalIndent(target, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(output)); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
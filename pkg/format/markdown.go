@@ -0,0 +1,83 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// Markdown renders each result as a Markdown link card: a title link, the
+// description as a blockquote and the preview image.
+type Markdown struct{}
+
+// Format writes results as Markdown link cards separated by blank lines.
+func (m *Markdown) Format(w io.Writer, results []*ogp.Result) error {
+	cards := make([]string, 0, len(results))
+	for _, r := range results {
+		cards = append(cards, markdownCard(r))
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(cards, "\n")); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+	return nil
+}
+
+func markdownCard(r *ogp.Result) string {
+	title := r.Title
+	if title == "" {
+		title = r.URL
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "### [%s](%s)\n", escapeMarkdownText(title), escapeMarkdownURL(r.URL))
+	if desc := strings.TrimSpace(r.Description); desc != "" {
+		sb.WriteString("\n")
+		for line := range strings.SplitSeq(desc, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				sb.WriteString(">\n")
+				continue
+			}
+			fmt.Fprintf(&sb, "> %s\n", escapeMarkdownText(line))
+		}
+	}
+	if r.Image != "" {
+		fmt.Fprintf(&sb, "\n![%s](%s)\n", escapeMarkdownText(title), escapeMarkdownURL(r.Image))
+	}
+	return sb.String()
+}
+
+var markdownTextReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+	"|", `\|`,
+	"\r", "",
+	"\n", " ",
+)
+
+var markdownURLReplacer = strings.NewReplacer(
+	" ", "%20",
+	"(", "%28",
+	")", "%29",
+	"<", "%3C",
+	">", "%3E",
+	"\n", "",
+	"\r", "",
+)
+
+func escapeMarkdownText(s string) string {
+	return markdownTextReplacer.Replace(s)
+}
+
+func escapeMarkdownURL(s string) string {
+	return markdownURLReplacer.Replace(s)
+}
@@ -0,0 +1,49 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+func TestMarkdown_Format(t *testing.T) {
+	tests := map[string]struct {
+		result *ogp.Result
+		want   string
+	}{
+		"full card": {
+			result: &ogp.Result{
+				URL:         "https://example.com/page",
+				Title:       "Example",
+				Description: "An example page",
+				Image:       "https://example.com/img.png",
+			},
+			want: "### [Example](https://example.com/page)\n\n> An example page\n\n![Example](https://example.com/img.png)\n\n",
+		},
+		"title falls back to URL": {
+			result: &ogp.Result{URL: "https://example.com"},
+			want:   "### [https://example.com](https://example.com)\n\n",
+		},
+		"escapes markdown syntax": {
+			result: &ogp.Result{
+				URL:         "https://example.com/a (b)",
+				Title:       "[Hello] *world*",
+				Description: "line1\nline2",
+			},
+			want: "### [\\[Hello\\] \\*world\\*](https://example.com/a%20%28b%29)\n\n> line1\n> line2\n\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (&Markdown{}).Format(&buf, []*ogp.Result{tc.result}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("Format() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// Slack Block Kit limits.
+// ref: https://api.slack.com/reference/block-kit/blocks
+const (
+	slackMaxBlocks       = 50
+	slackMaxSectionText  = 3000
+	slackMaxContextText  = 3000
+	slackMaxImageURL     = 3000
+	slackMaxAltText      = 2000
+	slackMaxFallbackText = 4000
+	slackMaxTitle        = 150
+)
+
+// SlackMessage is a Slack incoming webhook message using Block Kit.
+type SlackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block.
+type SlackBlock struct {
+	Type      string          `json:"type"`
+	Text      *SlackText      `json:"text,omitempty"`
+	Accessory *SlackElement   `json:"accessory,omitempty"`
+	Elements  []*SlackElement `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object.
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackElement is a Block Kit text or image element.
+type SlackElement struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	AltText  string `json:"alt_text,omitempty"`
+}
+
+// Slack renders results as Slack Block Kit messages. Each result becomes a
+// section with the title link and description, the preview image as an
+// accessory, and a context line with the site name.
+type Slack struct{}
+
+// Format writes the Slack messages as JSON.
+func (s *Slack) Format(w io.Writer, results []*ogp.Result) error {
+	payloads, err := s.Payloads(results)
+	if err != nil {
+		return err
+	}
+	return writePayloads(w, payloads)
+}
+
+// Payloads builds Slack messages, splitting results so that no message
+// exceeds the Block Kit block limit.
+func (s *Slack) Payloads(results []*ogp.Result) ([]any, error) {
+	var (
+		payloads []any
+		msg      *SlackMessage
+		texts    []string
+	)
+	flush := func() {
+		if msg == nil {
+			return
+		}
+		msg.Text = truncate(strings.Join(texts, "\n"), slackMaxFallbackText)
+		payloads = append(payloads, msg)
+		msg, texts = nil, nil
+	}
+
+	for _, r := range results {
+		blocks := slackCard(r)
+		if msg != nil && len(msg.Blocks)+1+len(blocks) > slackMaxBlocks {
+			flush()
+		}
+		if msg == nil {
+			msg = &SlackMessage{}
+		} else {
+			msg.Blocks = append(msg.Blocks, SlackBlock{Type: "divider"})
+		}
+		msg.Blocks = append(msg.Blocks, blocks...)
+		texts = append(texts, fmt.Sprintf("%s %s", cardTitle(r), r.URL))
+	}
+	flush()
+	return payloads, nil
+}
+
+func slackCard(r *ogp.Result) []SlackBlock {
+	title := escapeSlack(truncate(cardTitle(r), slackMaxTitle))
+	text := "*" + title + "*"
+	if isWebURL(r.URL) && !strings.ContainsAny(r.URL, "|<>") {
+		if linked := fmt.Sprintf("*<%s|%s>*", r.URL, title); runeLen(linked) <= slackMaxSectionText {
+			text = linked
+		}
+	}
+	if desc := strings.TrimSpace(r.Description); desc != "" {
+		if limit := slackMaxSectionText - runeLen(text) - 1; limit > 0 {
+			text += "\n" + escapeSlackTruncate(desc, limit)
+		}
+	}
+
+	section := SlackBlock{
+		Type: "section",
+		Text: &SlackText{Type: "mrkdwn", Text: text},
+	}
+	if isSlackImage(r.Image) {
+		section.Accessory = &SlackElement{
+			Type:     "image",
+			ImageURL: r.Image,
+			AltText:  truncate(cardTitle(r), slackMaxAltText),
+		}
+	}
+
+	blocks := []SlackBlock{section}
+	if name := siteName(r); name != "" {
+		blocks = append(blocks, SlackBlock{
+			Type: "context",
+			Elements: []*SlackElement{{
+				Type: "mrkdwn",
+				Text: escapeSlackTruncate(name, slackMaxContextText),
+			}},
+		})
+	}
+	return blocks
+}
+
+// isSlackImage reports whether Slack can render the image URL: it must be a
+// public http(s) URL within the length limit.
+func isSlackImage(s string) bool {
+	return s != "" && isWebURL(s) && len(s) <= slackMaxImageURL
+}
+
+var slackReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeSlack escapes the control characters of Slack mrkdwn.
+func escapeSlack(s string) string {
+	return slackReplacer.Replace(s)
+}
+
+// escapeSlackTruncate escapes s after truncating it so that the escaped text
+// fits in limit runes; cutting after escaping could split an entity.
+func escapeSlackTruncate(s string, limit int) string {
+	for n := runeLen(s); ; {
+		escaped := escapeSlack(truncate(s, n))
+		over := runeLen(escaped) - limit
+		if over <= 0 || n <= 0 {
+			return escaped
+		}
+		n = max(n-over, 0)
+	}
+}
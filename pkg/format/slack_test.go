@@ -0,0 +1,111 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+func TestSlack_Payloads_Card(t *testing.T) {
+	results := []*ogp.Result{{
+		URL:         "https://example.com/page",
+		Title:       "A <b> & C",
+		Description: strings.Repeat("x", 5000),
+		Image:       "https://example.com/img.png",
+		SiteName:    "Example",
+	}}
+
+	payloads, err := (&Slack{}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payloads) != 1 {
+		t.Fatalf("got %d payloads, want 1", len(payloads))
+	}
+	msg := payloads[0].(*SlackMessage)
+	if len(msg.Blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(msg.Blocks))
+	}
+
+	section := msg.Blocks[0]
+	if !strings.HasPrefix(section.Text.Text, "*<https://example.com/page|A &lt;b&gt; &amp; C>*\n") {
+		t.Errorf("unexpected section text prefix: %q", section.Text.Text[:60])
+	}
+	if n := runeLen(section.Text.Text); n > slackMaxSectionText {
+		t.Errorf("section text has %d chars, want <= %d", n, slackMaxSectionText)
+	}
+	if section.Accessory == nil || section.Accessory.ImageURL != "https://example.com/img.png" {
+		t.Errorf("unexpected accessory: %+v", section.Accessory)
+	}
+	if got := msg.Blocks[1].Elements[0].Text; got != "Example" {
+		t.Errorf("got context %q, want %q", got, "Example")
+	}
+	if msg.Text == "" {
+		t.Error("expected fallback text")
+	}
+}
+
+func TestSlack_Payloads_SkipsNonWebImage(t *testing.T) {
+	results := []*ogp.Result{{URL: "https://example.com", Title: "T", Image: "data:image/png;base64,AAAA"}}
+
+	payloads, err := (&Slack{}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := payloads[0].(*SlackMessage).Blocks[0].Accessory; got != nil {
+		t.Errorf("expected no accessory, got %+v", got)
+	}
+}
+
+func TestSlack_Payloads_SplitsAtBlockLimit(t *testing.T) {
+	var results []*ogp.Result
+	for i := range 20 {
+		results = append(results, &ogp.Result{URL: fmt.Sprintf("https://example.com/%d", i), Title: "T"})
+	}
+
+	payloads, err := (&Slack{}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payloads) < 2 {
+		t.Fatalf("got %d payloads, want at least 2", len(payloads))
+	}
+	for _, p := range payloads {
+		if n := len(p.(*SlackMessage).Blocks); n > slackMaxBlocks {
+			t.Errorf("message has %d blocks, want <= %d", n, slackMaxBlocks)
+		}
+	}
+}
+
+func TestSlack_Payloads_TruncatesBeforeEscaping(t *testing.T) {
+	results := []*ogp.Result{{
+		URL:         "https://example.com/page",
+		Title:       "T",
+		Description: strings.Repeat("&", 2000),
+	}}
+
+	payloads, err := (&Slack{}).Payloads(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := payloads[0].(*SlackMessage).Blocks[0].Text.Text
+	if n := runeLen(text); n > slackMaxSectionText {
+		t.Errorf("section text has %d chars, want <= %d", n, slackMaxSectionText)
+	}
+	if !strings.HasPrefix(text, "*<https://example.com/page|T>*\n") {
+		t.Errorf("unexpected section text prefix: %q", text[:40])
+	}
+	if body := strings.TrimSuffix(strings.SplitN(text, "\n", 2)[1], "…"); strings.ReplaceAll(body, "&amp;", "") != "" {
+		t.Errorf("description contains a split entity: %q", body[len(body)-10:])
+	}
+}
+
+func TestNew_SlackRejectsColor(t *testing.T) {
+	for _, color := range []int{0x123456, 0} {
+		if _, err := New(SlackFormat, Options{Color: &color}); err == nil {
+			t.Errorf("expected an error for color %#06x, got nil", color)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package imageutil
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// BlurHash encodes img as a BlurHash string with xComponents x yComponents
+// DCT components (1-9 each).
+// ref: https://github.com/woltapp/blurhash/blob/master/Algorithm.md
+func BlurHash(img image.Image, xComponents, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", fmt.Errorf("blurhash components must be between 1 and 9, got %dx%d", xComponents, yComponents)
+	}
+	src := toRGBA(img)
+	b := src.Bounds()
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return "", fmt.Errorf("blurhash of empty image")
+	}
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := range yComponents {
+		for i := range xComponents {
+			factors = append(factors, blurHashFactor(src, i, j))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(encodeBase83((xComponents-1)+(yComponents-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, f := range ac {
+			actualMax = max(actualMax, math.Abs(f[0]), math.Abs(f[1]), math.Abs(f[2]))
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maximumValue = float64(quantisedMax+1) / 166
+		sb.WriteString(encodeBase83(quantisedMax, 1))
+	} else {
+		sb.WriteString(encodeBase83(0, 1))
+	}
+
+	sb.WriteString(encodeBase83(encodeDC(dc), 4))
+	for _, f := range ac {
+		sb.WriteString(encodeBase83(encodeAC(f, maximumValue), 2))
+	}
+	return sb.String(), nil
+}
+
+func blurHashFactor(src *image.RGBA, i, j int) [3]float64 {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	var r, g, bl float64
+	for y := range h {
+		cy := math.Cos(math.Pi * float64(j) * float64(y) / float64(h))
+		for x := range w {
+			basis := math.Cos(math.Pi*float64(i)*float64(x)/float64(w)) * cy
+			off := src.PixOffset(b.Min.X+x, b.Min.Y+y)
+			r += basis * sRGBToLinear(src.Pix[off])
+			g += basis * sRGBToLinear(src.Pix[off+1])
+			bl += basis * sRGBToLinear(src.Pix[off+2])
+		}
+	}
+	normalisation := 2.0
+	if i == 0 && j == 0 {
+		normalisation = 1
+	}
+	scale := normalisation / float64(w*h)
+	return [3]float64{r * scale, g * scale, bl * scale}
+}
+
+func encodeDC(f [3]float64) int {
+	return linearToSRGB(f[0])<<16 + linearToSRGB(f[1])<<8 + linearToSRGB(f[2])
+}
+
+func encodeAC(f [3]float64, maximumValue float64) int {
+	quant := func(v float64) int {
+		return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maximumValue, 0.5)*9+9.5))))
+	}
+	return quant(f[0])*19*19 + quant(f[1])*19 + quant(f[2])
+}
+
+func encodeBase83(value, length int) string {
+	out := make([]byte, length)
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		out[i-1] = base83Chars[digit]
+	}
+	return string(out)
+}
+
+func sRGBToLinear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	c := math.Max(0, math.Min(1, v))
+	if c <= 0.0031308 {
+		return int(c*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(c, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
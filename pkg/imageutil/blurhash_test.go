@@ -0,0 +1,55 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func solidImage(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func TestBlurHash_SolidColor(t *testing.T) {
+	hash, err := BlurHash(solidImage(32, 32, color.RGBA{R: 255, A: 255}), 4, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// size flag "L" is 4x3 components, followed by the max AC value and the
+	// average color (DC) in 4 characters
+	if hash[0] != 'L' {
+		t.Errorf("got size flag %q, want %q", hash[0], 'L')
+	}
+	if got, want := hash[2:6], encodeBase83(0xff0000, 4); got != want {
+		t.Errorf("got DC %q, want %q", got, want)
+	}
+}
+
+func TestBlurHash_Length(t *testing.T) {
+	img := solidImage(20, 10, color.White)
+	draw.Draw(img, image.Rect(0, 0, 10, 10), image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+	hash, err := BlurHash(img, 4, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 1 size + 1 max AC + 4 DC + 2 per AC component
+	if len(hash) != 1+1+4+2*11 {
+		t.Errorf("got length %d for %q", len(hash), hash)
+	}
+	if hash[1] == '0' {
+		t.Errorf("expected non-zero AC maximum for a two-tone image: %q", hash)
+	}
+}
+
+func TestBlurHash_InvalidComponents(t *testing.T) {
+	if _, err := BlurHash(solidImage(4, 4, color.White), 0, 3); err == nil {
+		t.Error("expected error for 0 components")
+	}
+	if _, err := BlurHash(solidImage(4, 4, color.White), 4, 10); err == nil {
+		t.Error("expected error for 10 components")
+	}
+}
@@ -0,0 +1,61 @@
+package imageutil
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// dominantSampleSize bounds the image used for color analysis.
+const dominantSampleSize = 64
+
+// DominantColor returns the most common color of img. Pixels are grouped into
+// coarse buckets of 4 bits per channel and the average color of the most
+// populated bucket is returned. Mostly transparent pixels are ignored.
+func DominantColor(img image.Image) (color.RGBA, bool) {
+	src := toRGBA(Resize(img, dominantSampleSize, dominantSampleSize))
+	b := src.Bounds()
+
+	type bucket struct {
+		r, g, b, n int
+	}
+	buckets := make(map[int]*bucket)
+	var best *bucket
+	for y := range b.Dy() {
+		off := src.PixOffset(b.Min.X, b.Min.Y+y)
+		for range b.Dx() {
+			r, g, bl, a := int(src.Pix[off]), int(src.Pix[off+1]), int(src.Pix[off+2]), int(src.Pix[off+3])
+			off += 4
+			if a < 128 {
+				continue
+			}
+			key := (r>>4)<<8 | (g>>4)<<4 | bl>>4
+			bk, ok := buckets[key]
+			if !ok {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.r += r
+			bk.g += g
+			bk.b += bl
+			bk.n++
+			if best == nil || bk.n > best.n {
+				best = bk
+			}
+		}
+	}
+	if best == nil {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{
+		R: uint8(best.r / best.n), //nolint:gosec // G115: average of uint8 values
+		G: uint8(best.g / best.n), //nolint:gosec // G115: average of uint8 values
+		B: uint8(best.b / best.n), //nolint:gosec // G115: average of uint8 values
+		A: 255,
+	}, true
+}
+
+// HexColor formats c as "#rrggbb".
+func HexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,28 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestDominantColor(t *testing.T) {
+	img := solidImage(100, 100, color.RGBA{R: 10, G: 120, B: 200, A: 255})
+	draw.Draw(img, image.Rect(0, 0, 30, 100), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	got, ok := DominantColor(img)
+	if !ok {
+		t.Fatal("expected a dominant color")
+	}
+	if want := "#0a78c8"; HexColor(got) != want {
+		t.Errorf("got %s, want %s", HexColor(got), want)
+	}
+}
+
+func TestDominantColor_IgnoresTransparentPixels(t *testing.T) {
+	img := solidImage(10, 10, color.RGBA{})
+	if _, ok := DominantColor(img); ok {
+		t.Error("expected no dominant color for a transparent image")
+	}
+}
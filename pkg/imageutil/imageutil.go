@@ -0,0 +1,89 @@
+// Package imageutil provides pure Go helpers to decode, resize and encode
+// preview images.
+package imageutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+
+	_ "golang.org/x/image/webp" // register the WebP decoder
+)
+
+// Output formats supported by Encode.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+const (
+	// DefaultJPEGQuality is the JPEG quality used by Encode.
+	DefaultJPEGQuality = 85
+	// MaxPixels bounds the decoded size of an image to guard against
+	// decompression bombs.
+	MaxPixels = 50_000_000
+)
+
+// ErrNotImage is returned when data is not a decodable image.
+var ErrNotImage = errors.New("not a supported image")
+
+// Sniff returns the MIME type detected from the first bytes of data, and
+// whether it is an image type.
+func Sniff(data []byte) (string, bool) {
+	mimeType := http.DetectContentType(data)
+	return mimeType, strings.HasPrefix(mimeType, "image/")
+}
+
+// DecodeConfig returns the dimensions and format of an image without decoding
+// the pixel data. It only needs the first bytes of the image.
+func DecodeConfig(data []byte) (image.Config, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return image.Config{}, "", fmt.Errorf("%w: %v", ErrNotImage, err)
+	}
+	return cfg, format, nil
+}
+
+// Decode decodes a JPEG, PNG, GIF or WebP image, rejecting images larger than
+// MaxPixels before allocating them. Only the first frame of a GIF is used.
+func Decode(data []byte) (image.Image, string, error) {
+	cfg, format, err := DecodeConfig(data)
+	if err != nil {
+		return nil, "", err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, "", fmt.Errorf("%w: invalid dimensions %dx%d", ErrNotImage, cfg.Width, cfg.Height)
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrNotImage, err)
+	}
+	return img, format, nil
+}
+
+// Encode writes img in the given format ("jpeg" or "png").
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case FormatJPEG, "jpg":
+		return jpeg.Encode(w, Flatten(img, color.White), &jpeg.Options{Quality: DefaultJPEGQuality})
+	case FormatPNG:
+		return png.Encode(w, img)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// ContentType returns the MIME type of an output format.
+func ContentType(format string) string {
+	if format == FormatPNG {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
@@ -0,0 +1,92 @@
+package imageutil
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSniff(t *testing.T) {
+	if mimeType, ok := Sniff(encodePNG(t, 2, 2)); !ok || mimeType != "image/png" {
+		t.Errorf("Sniff(png) = %q, %v", mimeType, ok)
+	}
+	if mimeType, ok := Sniff([]byte("<html><body>hi</body></html>")); ok {
+		t.Errorf("Sniff(html) = %q, %v, want not image", mimeType, ok)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	img, format, err := Decode(encodePNG(t, 4, 3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if format != "png" || img.Bounds().Dx() != 4 || img.Bounds().Dy() != 3 {
+		t.Errorf("got %s %v", format, img.Bounds())
+	}
+
+	_, _, err = Decode([]byte("not an image"))
+	if !errors.Is(err, ErrNotImage) {
+		t.Errorf("got %v, want ErrNotImage", err)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+
+	for _, format := range []string{FormatJPEG, FormatPNG} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, img, format); err != nil {
+			t.Fatalf("Encode(%s): %v", format, err)
+		}
+		if _, got, err := image.DecodeConfig(&buf); err != nil || got != format {
+			t.Errorf("Encode(%s) produced %q: %v", format, got, err)
+		}
+	}
+
+	if err := Encode(&bytes.Buffer{}, img, "bmp"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+// webpHeader returns the header of a lossless WebP image, which is enough
+// for DecodeConfig.
+func webpHeader(w, h int) []byte {
+	bits := uint32(w-1) | uint32(h-1)<<14
+	vp8l := []byte{0x2f, byte(bits), byte(bits >> 8), byte(bits >> 16), byte(bits >> 24)}
+	chunk := append([]byte("VP8L"), byte(len(vp8l)), 0, 0, 0)
+	chunk = append(chunk, vp8l...)
+	riff := append([]byte("WEBP"), chunk...)
+	return append([]byte{'R', 'I', 'F', 'F', byte(len(riff)), 0, 0, 0}, riff...)
+}
+
+func TestDecodeConfig_WebP(t *testing.T) {
+	data := webpHeader(1200, 630)
+	if mimeType, ok := Sniff(data); !ok || mimeType != "image/webp" {
+		t.Errorf("Sniff(webp) = %q, %v", mimeType, ok)
+	}
+	cfg, format, err := DecodeConfig(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if format != "webp" || cfg.Width != 1200 || cfg.Height != 630 {
+		t.Errorf("got %s %dx%d, want webp 1200x630", format, cfg.Width, cfg.Height)
+	}
+}
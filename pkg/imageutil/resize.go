@@ -0,0 +1,94 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Fit returns the largest size within maxWidth x maxHeight that keeps the
+// aspect ratio of width x height. A zero bound is unconstrained. Images are
+// never upscaled.
+func Fit(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= 0 || height <= 0 {
+		return 0, 0
+	}
+	scale := 1.0
+	if maxWidth > 0 && width > maxWidth {
+		scale = float64(maxWidth) / float64(width)
+	}
+	if maxHeight > 0 && float64(height)*scale > float64(maxHeight) {
+		scale = float64(maxHeight) / float64(height)
+	}
+	w := max(1, int(float64(width)*scale+0.5))
+	h := max(1, int(float64(height)*scale+0.5))
+	return w, h
+}
+
+// Resize scales img to fit within maxWidth x maxHeight, keeping its aspect
+// ratio. Downscaling averages all source pixels covered by each destination
+// pixel, which avoids the aliasing of nearest-neighbour sampling.
+func Resize(img image.Image, maxWidth, maxHeight int) image.Image {
+	b := img.Bounds()
+	w, h := Fit(b.Dx(), b.Dy(), maxWidth, maxHeight)
+	if w == b.Dx() && h == b.Dy() {
+		return img
+	}
+
+	src := toRGBA(img)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	sx := float64(b.Dx()) / float64(w)
+	sy := float64(b.Dy()) / float64(h)
+
+	for y := range h {
+		y0 := int(float64(y) * sy)
+		y1 := max(y0+1, int(float64(y+1)*sy))
+		for x := range w {
+			x0 := int(float64(x) * sx)
+			x1 := max(x0+1, int(float64(x+1)*sx))
+			dst.SetRGBA(x, y, averageRGBA(src, x0, y0, x1, y1))
+		}
+	}
+	return dst
+}
+
+// averageRGBA averages the premultiplied pixels of src in [x0,x1) x [y0,y1),
+// where coordinates are relative to the bounds origin.
+func averageRGBA(src *image.RGBA, x0, y0, x1, y1 int) color.RGBA {
+	b := src.Bounds()
+	var r, g, bl, a, n uint64
+	for y := y0; y < y1 && y < b.Dy(); y++ {
+		off := src.PixOffset(b.Min.X+x0, b.Min.Y+y)
+		for x := x0; x < x1 && x < b.Dx(); x++ {
+			r += uint64(src.Pix[off])
+			g += uint64(src.Pix[off+1])
+			bl += uint64(src.Pix[off+2])
+			a += uint64(src.Pix[off+3])
+			n++
+			off += 4
+		}
+	}
+	if n == 0 {
+		return color.RGBA{}
+	}
+	return color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: uint8(a / n)} //nolint:gosec // G115: averages of uint8 values fit in uint8
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+// Flatten draws img over an opaque background, as JPEG has no alpha channel.
+func Flatten(img image.Image, bg color.Color) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return dst
+}
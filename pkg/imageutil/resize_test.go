@@ -0,0 +1,63 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := map[string]struct {
+		w, h, maxW, maxH int
+		wantW, wantH     int
+	}{
+		"width bound":        {w: 1200, h: 630, maxW: 600, wantW: 600, wantH: 315},
+		"height bound":       {w: 1200, h: 630, maxH: 315, wantW: 600, wantH: 315},
+		"both bounds":        {w: 1000, h: 1000, maxW: 400, maxH: 200, wantW: 200, wantH: 200},
+		"no upscale":         {w: 100, h: 50, maxW: 400, maxH: 400, wantW: 100, wantH: 50},
+		"unbounded":          {w: 100, h: 50, wantW: 100, wantH: 50},
+		"keeps at least one": {w: 1000, h: 1, maxW: 10, wantW: 10, wantH: 1},
+		"invalid size":       {w: 0, h: 10, maxW: 10, wantW: 0, wantH: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, h := Fit(tc.w, tc.h, tc.maxW, tc.maxH)
+			if w != tc.wantW || h != tc.wantH {
+				t.Errorf("Fit(%d, %d, %d, %d) = %d, %d, want %d, %d", tc.w, tc.h, tc.maxW, tc.maxH, w, h, tc.wantW, tc.wantH)
+			}
+		})
+	}
+}
+
+func TestResize_AveragesPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := range 2 {
+		for x := range 4 {
+			c := color.RGBA{A: 255}
+			if x%2 == 0 {
+				c.R = 255
+			} else {
+				c.B = 255
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+
+	dst := Resize(src, 2, 0)
+	if b := dst.Bounds(); b.Dx() != 2 || b.Dy() != 1 {
+		t.Fatalf("got bounds %v, want 2x1", b)
+	}
+	got := color.RGBAModel.Convert(dst.At(0, 0)).(color.RGBA)
+	want := color.RGBA{R: 127, B: 127, A: 255}
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestResize_NoopWhenSmaller(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	if dst := Resize(src, 100, 100); dst != src {
+		t.Error("expected the source image to be returned unchanged")
+	}
+}
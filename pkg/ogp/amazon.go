@@ -0,0 +1,160 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+var (
+	amazonHostPattern = regexp.MustCompile(`^(?:www\.|smile\.|m\.)?amazon\.(com|co\.jp|co\.uk|de|fr|it|es|nl|ca|com\.au|com\.mx|com\.br|in)$`)
+	// amazonASINPattern matches the product paths of Amazon, with or without a slug or ref segments.
+	amazonASINPattern  = regexp.MustCompile(`/(?:dp|gp/product|gp/aw/d|exec/obidos/ASIN|o/ASIN)/([A-Z0-9]{10})(?:/|$)`)
+	amazonBrandPattern = regexp.MustCompile(`^(?:Visit the (.+) Store|Brand: (.+)|ブランド: (.+)|(.+)のストアを表示)$`)
+	// amazonShortHosts redirect to Amazon product pages.
+	amazonShortHosts = []string{"amzn.to", "amzn.asia", "amzn.eu", "a.co"}
+	// amazonCurrencies are the currencies of the Amazon stores by TLD, which
+	// tell apart the dollars of amazon.com, .ca, .com.au and .com.mx.
+	amazonCurrencies = map[string]string{
+		"com": "USD", "co.jp": "JPY", "co.uk": "GBP", "de": "EUR", "fr": "EUR", "it": "EUR", "es": "EUR", "nl": "EUR",
+		"ca": "CAD", "com.au": "AUD", "com.mx": "MXN", "com.br": "BRL", "in": "INR",
+	}
+
+	amazonTitleSelector        = cascadia.MustCompile(`#productTitle`)
+	amazonImageSelector        = cascadia.MustCompile(`#landingImage, #imgBlkFront`)
+	amazonPriceSelector        = cascadia.MustCompile(`#corePrice_feature_div .a-offscreen, #corePriceDisplay_desktop_feature_div .a-offscreen, #priceblock_ourprice, #price_inside_buybox`)
+	amazonBrandSelector        = cascadia.MustCompile(`#bylineInfo`)
+	amazonRatingSelector       = cascadia.MustCompile(`#acrPopover`)
+	amazonReviewCountSelector  = cascadia.MustCompile(`#acrCustomerReviewText`)
+	amazonAvailabilitySelector = cascadia.MustCompile(`#availability`)
+	amazonCanonicalSelector    = cascadia.MustCompile(`link[rel="canonical"]`)
+)
+
+// IsAmazonURL checks if the URL is an Amazon product page or a short link
+// such as amzn.to.
+func IsAmazonURL(targetURL string) bool {
+	_, _, _, ok := canonicalAmazonURL(targetURL)
+	return ok || isAmazonShortURL(targetURL)
+}
+
+func isAmazonShortURL(targetURL string) bool {
+	parsed, err := url.Parse(targetURL)
+	return err == nil && slices.Contains(amazonShortHosts, strings.ToLower(parsed.Hostname())) &&
+		strings.Trim(parsed.Path, "/") != ""
+}
+
+// canonicalAmazonURL returns https://www.amazon.<tld>/dp/<ASIN> for an Amazon
+// product URL, dropping the slug, ref segments and affiliate and tracking
+// parameters, along with the ASIN and the currency of the store.
+func canonicalAmazonURL(targetURL string) (string, string, string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return "", "", "", false
+	}
+	m := amazonHostPattern.FindStringSubmatch(strings.ToLower(parsed.Hostname()))
+	if m == nil {
+		return "", "", "", false
+	}
+	asin := amazonASINPattern.FindStringSubmatch(parsed.Path)
+	if asin == nil {
+		return "", "", "", false
+	}
+	return "https://www.amazon." + m[1] + "/dp/" + asin[1], asin[1], amazonCurrencies[m[1]], true
+}
+
+func (f *Fetcher) fetchAmazon(ctx context.Context, targetURL string) *Result {
+	canonical, asin, currency, ok := canonicalAmazonURL(targetURL)
+	if ok {
+		return f.fetchPage(ctx, canonical, func(doc *html.Node, fallback *HTMLFallbackData) {
+			extractAmazonProduct(doc, fallback, asin, currency)
+		})
+	}
+
+	// a short link redirects to the product page, whose canonical link
+	// names the store and the product
+	result := f.fetchPage(ctx, targetURL, func(doc *html.Node, fallback *HTMLFallbackData) {
+		if n := cascadia.Query(doc, amazonCanonicalSelector); n != nil {
+			canonical, asin, currency, ok = canonicalAmazonURL(getAttr(n, "href"))
+		}
+		if ok {
+			extractAmazonProduct(doc, fallback, asin, currency)
+		}
+	})
+	if ok && result.Err == nil {
+		result.URL = canonical
+	}
+	return result
+}
+
+// extractAmazonProduct reads the product details of the Amazon page layout,
+// which carries no structured product data.
+// The store currency comes before the one the price symbol implies.
+func extractAmazonProduct(doc *html.Node, fallback *HTMLFallbackData, asin, currency string) {
+	c := &fallback.product
+	c.add(ProductFieldSKU, SourceSelector, asin)
+	c.add(ProductFieldCurrency, SourceSelector, currency)
+	if n := cascadia.Query(doc, amazonTitleSelector); n != nil {
+		title := collapseSpace(nodeText(n))
+		fallback.addCandidate(FieldTitle, SourceSelector, title)
+		c.add(ProductFieldName, SourceSelector, title)
+	}
+	if n := cascadia.Query(doc, amazonImageSelector); n != nil {
+		image := getAttr(n, "data-old-hires")
+		if image == "" {
+			image = getAttr(n, "src")
+		}
+		fallback.addCandidate(FieldImage, SourceSelector, image)
+	}
+	if n := cascadia.Query(doc, amazonPriceSelector); n != nil {
+		c.addPrice(SourceSelector, nodeText(n))
+	}
+	if n := cascadia.Query(doc, amazonBrandSelector); n != nil {
+		c.add(ProductFieldBrand, SourceSelector, amazonBrand(collapseSpace(nodeText(n))))
+	}
+	if n := cascadia.Query(doc, amazonRatingSelector); n != nil {
+		// "4.5 out of 5 stars" or "5つ星のうち4.5"
+		rating := getAttr(n, "title")
+		if _, after, ok := strings.Cut(rating, "うち"); ok {
+			rating = after
+		}
+		c.add(ProductFieldRating, SourceSelector, rating)
+	}
+	if n := cascadia.Query(doc, amazonReviewCountSelector); n != nil {
+		c.add(ProductFieldReviewCount, SourceSelector, nodeText(n))
+	}
+	if n := cascadia.Query(doc, amazonAvailabilitySelector); n != nil {
+		c.add(ProductFieldAvailability, SourceSelector, amazonAvailability(collapseSpace(nodeText(n))))
+	}
+	fallback.Product = c.build()
+}
+
+// amazonBrand strips the store link wording around a brand name.
+func amazonBrand(s string) string {
+	if m := amazonBrandPattern.FindStringSubmatch(s); m != nil {
+		for _, brand := range m[1:] {
+			if brand != "" {
+				return brand
+			}
+		}
+	}
+	return s
+}
+
+// amazonAvailability maps the availability message of a page to a schema.org name.
+func amazonAvailability(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case strings.Contains(lower, "in stock"), strings.Contains(s, "在庫あり"):
+		return "InStock"
+	case strings.Contains(lower, "unavailable"), strings.Contains(lower, "out of stock"), strings.Contains(s, "在庫切れ"):
+		return "OutOfStock"
+	case strings.Contains(lower, "pre-order"), strings.Contains(s, "予約"):
+		return "PreOrder"
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalAmazonURL(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want string
+	}{
+		"slug and ref": {
+			url:  "https://www.amazon.co.jp/Go%E8%A8%80%E8%AA%9E/dp/4621300253/ref=sr_1_1?keywords=go&qid=1&sr=8-1",
+			want: "https://www.amazon.co.jp/dp/4621300253",
+		},
+		"affiliate tag": {url: "https://amazon.com/dp/B0C1234567?tag=someone-20&linkCode=ll1", want: "https://www.amazon.com/dp/B0C1234567"},
+		"gp product":    {url: "https://www.amazon.com/gp/product/B0C1234567/ref=ppx_yo_dt_b_asin_title", want: "https://www.amazon.com/dp/B0C1234567"},
+		"mobile":        {url: "https://m.amazon.co.jp/gp/aw/d/4621300253", want: "https://www.amazon.co.jp/dp/4621300253"},
+		"search":        {url: "https://www.amazon.com/s?k=gopher"},
+		"other host":    {url: "https://amazon.example.com/dp/B0C1234567"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, _, _, ok := canonicalAmazonURL(tc.url)
+			if got != tc.want || ok != (tc.want != "") {
+				t.Errorf("canonicalAmazonURL(%q) = %q, %v, want %q", tc.url, got, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_Amazon(t *testing.T) {
+	var gotURL string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			gotURL = req.URL.String()
+			html := `<html><head><title>Amazon.co.jp: Go言語 : 本</title>
+				<meta name="description" content="Go言語の本"></head><body>
+				<span id="productTitle"> プログラミング言語Go </span>
+				<a id="bylineInfo" href="/stores/gopher">ブランド: Gopher Books</a>
+				<span id="acrPopover" title="5つ星のうち4.5"></span>
+				<span id="acrCustomerReviewText">1,234個の評価</span>
+				<div id="corePrice_feature_div"><span class="a-price"><span class="a-offscreen">￥3,960</span></span></div>
+				<div id="availability"><span>在庫あり。</span></div>
+				<img id="landingImage" src="https://m.media-amazon.com/small.jpg" data-old-hires="https://m.media-amazon.com/large.jpg">
+			</body></html>`
+			return []byte(html), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://www.amazon.co.jp/Go/dp/4621300253/ref=sr_1_1?tag=someone-22")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if gotURL != "https://www.amazon.co.jp/dp/4621300253" || result.URL != gotURL {
+		t.Errorf("got request %q and URL %q", gotURL, result.URL)
+	}
+	if result.Title != "プログラミング言語Go" || result.Image != "https://m.media-amazon.com/large.jpg" {
+		t.Errorf("got title %q and image %q", result.Title, result.Image)
+	}
+	want := &Product{
+		Name: "プログラミング言語Go", Brand: "Gopher Books", SKU: "4621300253", Price: "3960", Currency: "JPY",
+		Availability: "InStock", Rating: 4.5, ReviewCount: 1234,
+		Sources: map[string]Source{
+			ProductFieldName: SourceSelector, ProductFieldBrand: SourceSelector, ProductFieldSKU: SourceSelector,
+			ProductFieldPrice: SourceSelector, ProductFieldCurrency: SourceSelector, ProductFieldAvailability: SourceSelector,
+			ProductFieldRating: SourceSelector, ProductFieldReviewCount: SourceSelector,
+		},
+	}
+	if !reflect.DeepEqual(result.Product, want) {
+		t.Errorf("got product %+v, want %+v", result.Product, want)
+	}
+}
+
+func TestFetch_Amazon_StoreCurrency(t *testing.T) {
+	tests := map[string]struct {
+		url          string
+		price        string
+		wantPrice    string
+		wantCurrency string
+	}{
+		"canada":    {url: "https://www.amazon.ca/dp/B0C1234567", price: "$24.99", wantPrice: "24.99", wantCurrency: "CAD"},
+		"mexico":    {url: "https://www.amazon.com.mx/dp/B0C1234567", price: "$1,299.00", wantPrice: "1299.00", wantCurrency: "MXN"},
+		"brazil":    {url: "https://www.amazon.com.br/dp/B0C1234567", price: "R$ 1.299,90", wantPrice: "1299.90", wantCurrency: "BRL"},
+		"germany":   {url: "https://www.amazon.de/dp/B0C1234567", price: "1.299 €", wantPrice: "1299", wantCurrency: "EUR"},
+		"us dollar": {url: "https://www.amazon.com/dp/B0C1234567", price: "$24.99", wantPrice: "24.99", wantCurrency: "USD"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeHTTPClient{
+				handler: func(req *http.Request) ([]byte, int, error) {
+					html := `<html><body><div id="corePrice_feature_div"><span class="a-offscreen">` + tc.price + `</span></div></body></html>`
+					return []byte(html), 200, nil
+				},
+			}
+			result := NewFetcher(client).Fetch(t.Context(), tc.url)
+			if result.Product == nil {
+				t.Fatal("got nil product")
+			}
+			if result.Product.Price != tc.wantPrice || result.Product.Currency != tc.wantCurrency {
+				t.Errorf("got %s %s, want %s %s", result.Product.Price, result.Product.Currency, tc.wantPrice, tc.wantCurrency)
+			}
+		})
+	}
+}
+
+func TestFetch_Amazon_ShortLink(t *testing.T) {
+	if !IsAmazonURL("https://amzn.asia/d/abc1234") || IsAmazonURL("https://amzn.to/") {
+		t.Error("IsAmazonURL does not match short links with a path only")
+	}
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			// the client follows the redirect to the product page
+			html := `<html><head><link rel="canonical" href="https://www.amazon.co.jp/Go/dp/4621300253"></head><body>
+				<span id="productTitle">プログラミング言語Go</span>
+				<div id="corePrice_feature_div"><span class="a-offscreen">￥3,960</span></div>
+			</body></html>`
+			return []byte(html), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://amzn.asia/d/abc1234")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.URL != "https://www.amazon.co.jp/dp/4621300253" || result.Title != "プログラミング言語Go" {
+		t.Errorf("got URL %q and title %q", result.URL, result.Title)
+	}
+	if result.Product == nil || result.Product.SKU != "4621300253" || result.Product.Currency != "JPY" {
+		t.Errorf("got product %+v", result.Product)
+	}
+}
@@ -0,0 +1,193 @@
+package ogp
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Article fields recorded in Article.Sources.
+const (
+	ArticleFieldPublished = "published"
+	ArticleFieldModified  = "modified"
+	ArticleFieldAuthors   = "authors"
+	ArticleFieldSection   = "section"
+	ArticleFieldTags      = "tags"
+)
+
+// articleSourcePriority orders the sources of article metadata, most trusted first.
+var articleSourcePriority = []Source{SourceAPI, SourceOG, SourceJSONLD, SourceDublinCore, SourceMeta, SourceTime}
+
+var articleJSONLDTypes = []string{
+	"Article", "NewsArticle", "BlogPosting", "TechArticle", "ScholarlyArticle",
+	"Report", "SocialMediaPosting", "LiveBlogPosting", "WebPage",
+}
+
+// Article holds bylines, dates and classification of an article. Dates are
+// RFC 3339. Likes is set by providers of platforms that show like counts.
+// Sources records which source each field came from.
+type Article struct {
+	Published string            `json:"published,omitempty"`
+	Modified  string            `json:"modified,omitempty"`
+	Authors   []string          `json:"authors,omitempty"`
+	Section   string            `json:"section,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	Likes     int               `json:"likes,omitempty"`
+	Sources   map[string]Source `json:"sources,omitempty"`
+}
+
+// articleCollector gathers article metadata values per field and source while
+// traversing a document.
+type articleCollector struct {
+	values map[string]map[Source][]string
+}
+
+func (c *articleCollector) add(field string, source Source, values ...string) {
+	if c.values == nil {
+		c.values = make(map[string]map[Source][]string)
+	}
+	if c.values[field] == nil {
+		c.values[field] = make(map[Source][]string)
+	}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			c.values[field][source] = append(c.values[field][source], v)
+		}
+	}
+}
+
+func (c *articleCollector) addDate(field string, source Source, value string) {
+	if t := normalizeTime(value); t != "" {
+		c.add(field, source, t)
+	}
+}
+
+// pick returns the values of the most trusted source that has any.
+func (c *articleCollector) pick(field string) ([]string, Source) {
+	for _, source := range articleSourcePriority {
+		if values := c.values[field][source]; len(values) > 0 {
+			return values, source
+		}
+	}
+	return nil, ""
+}
+
+// build returns the collected article, or nil when nothing was found.
+func (c *articleCollector) build() *Article {
+	a := &Article{Sources: make(map[string]Source)}
+	if v, src := c.pick(ArticleFieldPublished); len(v) > 0 {
+		a.Published, a.Sources[ArticleFieldPublished] = v[0], src
+	}
+	if v, src := c.pick(ArticleFieldModified); len(v) > 0 {
+		a.Modified, a.Sources[ArticleFieldModified] = v[0], src
+	}
+	if v, src := c.pick(ArticleFieldAuthors); len(v) > 0 {
+		a.Authors, a.Sources[ArticleFieldAuthors] = uniqueStrings(v), src
+	}
+	if v, src := c.pick(ArticleFieldSection); len(v) > 0 {
+		a.Section, a.Sources[ArticleFieldSection] = v[0], src
+	}
+	if v, src := c.pick(ArticleFieldTags); len(v) > 0 {
+		a.Tags, a.Sources[ArticleFieldTags] = uniqueStrings(v), src
+	}
+	if len(a.Sources) == 0 {
+		return nil
+	}
+	return a
+}
+
+// handleArticleMeta collects article metadata from OpenGraph article:*,
+// <meta name=author> and Dublin Core <meta> tags.
+func handleArticleMeta(c *articleCollector, property, name, content string) {
+	switch strings.TrimPrefix(property, "og:") {
+	case "article:published_time":
+		c.addDate(ArticleFieldPublished, SourceOG, content)
+	case "article:modified_time":
+		c.addDate(ArticleFieldModified, SourceOG, content)
+	case "article:author":
+		c.add(ArticleFieldAuthors, SourceOG, content)
+	case "article:section":
+		c.add(ArticleFieldSection, SourceOG, content)
+	case "article:tag":
+		c.add(ArticleFieldTags, SourceOG, content)
+	}
+
+	switch strings.ToLower(name) {
+	case "author":
+		c.add(ArticleFieldAuthors, SourceMeta, content)
+	case "dc.date", "dc.date.issued", "dc.date.created", "dcterms.issued", "dcterms.created", "dcterms.date":
+		c.addDate(ArticleFieldPublished, SourceDublinCore, content)
+	case "dc.date.modified", "dcterms.modified":
+		c.addDate(ArticleFieldModified, SourceDublinCore, content)
+	case "dc.creator", "dcterms.creator":
+		c.add(ArticleFieldAuthors, SourceDublinCore, content)
+	case "dc.subject", "dcterms.subject":
+		c.add(ArticleFieldTags, SourceDublinCore, content)
+	}
+}
+
+// handleTimeTag collects the first <time datetime> as a published date.
+func handleTimeTag(c *articleCollector, n *html.Node) {
+	if len(c.values[ArticleFieldPublished][SourceTime]) > 0 {
+		return
+	}
+	c.addDate(ArticleFieldPublished, SourceTime, getAttr(n, "datetime"))
+}
+
+// handleArticleJSONLD collects article metadata from JSON-LD objects.
+func handleArticleJSONLD(c *articleCollector, objects []map[string]any) {
+	for _, obj := range articleJSONLDObjects(objects) {
+		c.addDate(ArticleFieldPublished, SourceJSONLD, jsonLDString(obj["datePublished"]))
+		c.addDate(ArticleFieldModified, SourceJSONLD, jsonLDString(obj["dateModified"]))
+		c.add(ArticleFieldAuthors, SourceJSONLD, jsonLDStrings(obj["author"])...)
+		c.add(ArticleFieldSection, SourceJSONLD, jsonLDStrings(obj["articleSection"])...)
+		c.add(ArticleFieldTags, SourceJSONLD, jsonLDKeywords(obj["keywords"])...)
+	}
+}
+
+func articleJSONLDObjects(objects []map[string]any) []map[string]any {
+	var articles []map[string]any
+	for _, obj := range objects {
+		if hasJSONLDType(obj, articleJSONLDTypes...) {
+			articles = append(articles, obj)
+		}
+	}
+	return articles
+}
+
+// jsonLDKeywords returns keywords given as an array or a comma-separated string.
+func jsonLDKeywords(v any) []string {
+	if s, ok := v.(string); ok {
+		return strings.Split(s, ",")
+	}
+	return jsonLDStrings(v)
+}
+
+func uniqueStrings(values []string) []string {
+	var unique []string
+	for _, v := range values {
+		if !slices.Contains(unique, v) {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// apiArticle returns the article metadata a provider read from an API, or
+// nil when there is none.
+func apiArticle(published, modified string, authors, tags []string, likes int) *Article {
+	var c articleCollector
+	c.addDate(ArticleFieldPublished, SourceAPI, published)
+	c.addDate(ArticleFieldModified, SourceAPI, modified)
+	c.add(ArticleFieldAuthors, SourceAPI, authors...)
+	c.add(ArticleFieldTags, SourceAPI, tags...)
+	a := c.build()
+	if a == nil && likes > 0 {
+		a = &Article{Sources: make(map[string]Source)}
+	}
+	if a != nil {
+		a.Likes = likes
+	}
+	return a
+}
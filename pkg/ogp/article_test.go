@@ -0,0 +1,148 @@
+package ogp
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExtractHTMLFallback_Article(t *testing.T) {
+	tests := map[string]struct {
+		html string
+		want Article
+	}{
+		"opengraph article properties": {
+			html: `<head>
+				<meta property="article:published_time" content="2024-03-01T09:30:00+09:00">
+				<meta property="article:modified_time" content="2024-03-02">
+				<meta property="article:author" content="Alice">
+				<meta property="article:author" content="Bob">
+				<meta property="article:section" content="Tech">
+				<meta property="article:tag" content="go">
+				<meta property="article:tag" content="html">
+				<meta name="author" content="Someone Else">
+			</head>`,
+			want: Article{
+				Published: "2024-03-01T09:30:00+09:00",
+				Modified:  "2024-03-02T00:00:00Z",
+				Authors:   []string{"Alice", "Bob"},
+				Section:   "Tech",
+				Tags:      []string{"go", "html"},
+				Sources: map[string]Source{
+					ArticleFieldPublished: SourceOG,
+					ArticleFieldModified:  SourceOG,
+					ArticleFieldAuthors:   SourceOG,
+					ArticleFieldSection:   SourceOG,
+					ArticleFieldTags:      SourceOG,
+				},
+			},
+		},
+		"json-ld graph": {
+			html: `<head><script type="application/ld+json">
+				{"@context": "https://schema.org", "@graph": [
+					{"@type": "WebSite", "name": "Example"},
+					{"@type": ["NewsArticle"], "datePublished": "2024-03-01T09:30:00Z",
+					 "author": [{"@type": "Person", "name": "Alice"}, {"@type": "Person", "name": "Bob"}],
+					 "keywords": "go, html"}
+				]}
+			</script></head><body><time datetime="2020-01-01">old</time></body>`,
+			want: Article{
+				Published: "2024-03-01T09:30:00Z",
+				Authors:   []string{"Alice", "Bob"},
+				Tags:      []string{"go", "html"},
+				Sources: map[string]Source{
+					ArticleFieldPublished: SourceJSONLD,
+					ArticleFieldAuthors:   SourceJSONLD,
+					ArticleFieldTags:      SourceJSONLD,
+				},
+			},
+		},
+		"dublin core, meta author and time": {
+			html: `<head>
+				<meta name="DC.date" content="2024/03/01">
+				<meta name="author" content="Alice">
+			</head><body>
+				<time datetime="2020-01-01">old</time>
+				<time datetime="2024-03-05T10:00:00Z">modified</time>
+			</body>`,
+			want: Article{
+				Published: "2024-03-01T00:00:00Z",
+				Authors:   []string{"Alice"},
+				Sources: map[string]Source{
+					ArticleFieldPublished: SourceDublinCore,
+					ArticleFieldAuthors:   SourceMeta,
+				},
+			},
+		},
+		"time element only": {
+			html: `<body><time datetime="2024-03-01">1 March</time><time>undated</time></body>`,
+			want: Article{
+				Published: "2024-03-01T00:00:00Z",
+				Sources:   map[string]Source{ArticleFieldPublished: SourceTime},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			fallback, err := ExtractHTMLFallback(strings.NewReader(tc.html), "https://example.com")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := fallback.Article
+			if got == nil {
+				t.Fatal("got nil article")
+			}
+			if got.Published != tc.want.Published || got.Modified != tc.want.Modified || got.Section != tc.want.Section {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+			if !slices.Equal(got.Authors, tc.want.Authors) {
+				t.Errorf("got authors %q, want %q", got.Authors, tc.want.Authors)
+			}
+			if !slices.Equal(got.Tags, tc.want.Tags) {
+				t.Errorf("got tags %q, want %q", got.Tags, tc.want.Tags)
+			}
+			for field, source := range tc.want.Sources {
+				if got.Sources[field] != source {
+					t.Errorf("got source %q for %s, want %q", got.Sources[field], field, source)
+				}
+			}
+			if len(got.Sources) != len(tc.want.Sources) {
+				t.Errorf("got sources %v, want %v", got.Sources, tc.want.Sources)
+			}
+			if fallback.Published != tc.want.Published {
+				t.Errorf("got published %q, want %q", fallback.Published, tc.want.Published)
+			}
+		})
+	}
+}
+
+func TestExtractHTMLFallback_NoArticle(t *testing.T) {
+	fallback, err := ExtractHTMLFallback(strings.NewReader(`<head><title>Plain</title></head>`), "https://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fallback.Article != nil {
+		t.Errorf("got article %+v, want nil", fallback.Article)
+	}
+}
+
+func TestParseJSONLD(t *testing.T) {
+	tests := map[string]struct {
+		text string
+		want int
+	}{
+		"single object":   {text: `{"@type": "Article"}`, want: 1},
+		"top-level array": {text: `[{"@type": "Article"}, {"@type": "Person"}]`, want: 2},
+		"graph":           {text: `{"@graph": [{"@type": "Article"}, {"@type": "Person"}]}`, want: 3},
+		"invalid":         {text: `{"@type": `, want: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseJSONLD(tc.text); len(got) != tc.want {
+				t.Errorf("got %d objects, want %d", len(got), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const blueskySiteName = "Bluesky"
+
+// Bluesky embed view types.
+const (
+	blueskyEmbedImages          = "app.bsky.embed.images#view"
+	blueskyEmbedVideo           = "app.bsky.embed.video#view"
+	blueskyEmbedExternal        = "app.bsky.embed.external#view"
+	blueskyEmbedRecordWithMedia = "app.bsky.embed.recordWithMedia#view"
+)
+
+type blueskyProfile struct {
+	DID         string `json:"did"`
+	Handle      string `json:"handle"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Avatar      string `json:"avatar"`
+	Banner      string `json:"banner"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+type blueskyAspectRatio struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type blueskyEmbed struct {
+	Type   string `json:"$type"`
+	Images []struct {
+		Thumb       string             `json:"thumb"`
+		Fullsize    string             `json:"fullsize"`
+		Alt         string             `json:"alt"`
+		AspectRatio blueskyAspectRatio `json:"aspectRatio"`
+	} `json:"images"`
+	// video
+	Playlist    string             `json:"playlist"`
+	Thumbnail   string             `json:"thumbnail"`
+	Alt         string             `json:"alt"`
+	AspectRatio blueskyAspectRatio `json:"aspectRatio"`
+	External    *struct {
+		URI         string `json:"uri"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Thumb       string `json:"thumb"`
+	} `json:"external"`
+	// Media is the media of a quote post with media.
+	Media *blueskyEmbed `json:"media"`
+}
+
+type blueskyThreadResponse struct {
+	Thread struct {
+		Post *struct {
+			URI    string         `json:"uri"`
+			Author blueskyProfile `json:"author"`
+			Record struct {
+				Text      string `json:"text"`
+				CreatedAt string `json:"createdAt"`
+			} `json:"record"`
+			Embed       *blueskyEmbed `json:"embed"`
+			ReplyCount  int           `json:"replyCount"`
+			RepostCount int           `json:"repostCount"`
+			LikeCount   int           `json:"likeCount"`
+		} `json:"post"`
+	} `json:"thread"`
+}
+
+// blueskyRef is a bsky.app URL broken down into the actor and the post record key.
+type blueskyRef struct {
+	actor string
+	rkey  string
+}
+
+// IsBlueskyURL checks if the URL is a Bluesky profile or post URL.
+func IsBlueskyURL(targetURL string) bool {
+	_, ok := parseBlueskyURL(targetURL)
+	return ok
+}
+
+// parseBlueskyURL parses bsky.app/profile/<handle or DID>[/post/<rkey>].
+func parseBlueskyURL(targetURL string) (blueskyRef, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return blueskyRef{}, false
+	}
+	if host := strings.ToLower(parsed.Hostname()); host != "bsky.app" && host != "www.bsky.app" {
+		return blueskyRef{}, false
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "profile" || segments[1] == "" {
+		return blueskyRef{}, false
+	}
+	switch {
+	case len(segments) == 2:
+		return blueskyRef{actor: segments[1]}, true
+	case len(segments) == 4 && segments[2] == "post" && segments[3] != "":
+		return blueskyRef{actor: segments[1], rkey: segments[3]}, true
+	}
+	return blueskyRef{}, false
+}
+
+func (f *Fetcher) fetchBluesky(ctx context.Context, targetURL string) *Result {
+	ref, _ := parseBlueskyURL(targetURL)
+	result := &Result{URL: targetURL, SiteName: blueskySiteName}
+
+	var err error
+	if ref.rkey == "" {
+		err = f.fetchBlueskyProfile(ctx, result, ref)
+	} else {
+		err = f.fetchBlueskyPost(ctx, result, ref)
+	}
+	if err != nil {
+		log.Warnf("Bluesky API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
+
+// blueskyXRPC calls an XRPC method of the AppView.
+func (f *Fetcher) blueskyXRPC(ctx context.Context, method string, params url.Values, v any) error {
+	return f.fetchJSON(ctx, f.endpoint(EndpointBlueskyAPI)+"/xrpc/"+method+"?"+params.Encode(), v, nil)
+}
+
+// resolveBlueskyDID returns the DID of a handle. DIDs are returned as is.
+func (f *Fetcher) resolveBlueskyDID(ctx context.Context, actor string) (string, error) {
+	if strings.HasPrefix(actor, "did:") {
+		return actor, nil
+	}
+	var resp struct {
+		DID string `json:"did"`
+	}
+	if err := f.blueskyXRPC(ctx, "com.atproto.identity.resolveHandle", url.Values{"handle": {actor}}, &resp); err != nil {
+		return "", err
+	}
+	if resp.DID == "" {
+		return "", fmt.Errorf("handle %s did not resolve", actor)
+	}
+	return resp.DID, nil
+}
+
+func (f *Fetcher) fetchBlueskyProfile(ctx context.Context, result *Result, ref blueskyRef) error {
+	var profile blueskyProfile
+	if err := f.blueskyXRPC(ctx, "app.bsky.actor.getProfile", url.Values{"actor": {ref.actor}}, &profile); err != nil {
+		return err
+	}
+	result.Title = blueskyTitle(profile)
+	result.Description = profile.Description
+	result.Image = profile.Avatar
+	return nil
+}
+
+func (f *Fetcher) fetchBlueskyPost(ctx context.Context, result *Result, ref blueskyRef) error {
+	did, err := f.resolveBlueskyDID(ctx, ref.actor)
+	if err != nil {
+		return err
+	}
+	params := url.Values{
+		"uri":          {fmt.Sprintf("at://%s/app.bsky.feed.post/%s", did, ref.rkey)},
+		"depth":        {"0"},
+		"parentHeight": {"0"},
+	}
+	var resp blueskyThreadResponse
+	if err := f.blueskyXRPC(ctx, "app.bsky.feed.getPostThread", params, &resp); err != nil {
+		return err
+	}
+	p := resp.Thread.Post
+	if p == nil {
+		return fmt.Errorf("post %s not found", params.Get("uri"))
+	}
+
+	post := &Post{
+		Author:    p.Author.DisplayName,
+		Handle:    "@" + p.Author.Handle,
+		AuthorURL: "https://bsky.app/profile/" + p.Author.Handle,
+		Text:      p.Record.Text,
+		Created:   normalizeTime(p.Record.CreatedAt),
+		Replies:   p.ReplyCount,
+		Reposts:   p.RepostCount,
+		Likes:     p.LikeCount,
+	}
+	if post.Author == "" {
+		post.Author = p.Author.Handle
+	}
+	applyBlueskyEmbed(post, p.Embed)
+
+	result.Title = blueskyTitle(p.Author)
+	result.Description = excerpt(post.Text, maxExcerptLength)
+	result.Published = post.Created
+	result.Post = post
+	result.Image, result.ImageWidth, result.ImageHeight = previewImage(post.Media)
+	if result.Image == "" && post.Linked != nil {
+		result.Image = post.Linked.Image
+	}
+	if result.Image == "" {
+		result.Image = p.Author.Avatar
+	}
+	return nil
+}
+
+// applyBlueskyEmbed adds the images, video or external link card of an embed to post.
+func applyBlueskyEmbed(post *Post, embed *blueskyEmbed) {
+	if embed == nil {
+		return
+	}
+	switch embed.Type {
+	case blueskyEmbedImages:
+		for _, img := range embed.Images {
+			post.Media = append(post.Media, Media{
+				Type:       MediaImage,
+				URL:        img.Fullsize,
+				PreviewURL: img.Thumb,
+				Width:      img.AspectRatio.Width,
+				Height:     img.AspectRatio.Height,
+				Alt:        img.Alt,
+			})
+		}
+	case blueskyEmbedVideo:
+		post.Media = append(post.Media, Media{
+			Type:       MediaVideo,
+			URL:        embed.Playlist,
+			PreviewURL: embed.Thumbnail,
+			Width:      embed.AspectRatio.Width,
+			Height:     embed.AspectRatio.Height,
+			Alt:        embed.Alt,
+		})
+	case blueskyEmbedExternal:
+		if ext := embed.External; ext != nil {
+			post.Linked = &Result{URL: ext.URI, Title: ext.Title, Description: ext.Description, Image: ext.Thumb}
+			post.Linked.recordSources(SourceAPI)
+		}
+	case blueskyEmbedRecordWithMedia:
+		applyBlueskyEmbed(post, embed.Media)
+	}
+}
+
+func blueskyTitle(profile blueskyProfile) string {
+	if profile.DisplayName == "" {
+		return "@" + profile.Handle
+	}
+	return fmt.Sprintf("%s (@%s)", profile.DisplayName, profile.Handle)
+}
@@ -0,0 +1,137 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBlueskyURL(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		want   blueskyRef
+		wantOK bool
+	}{
+		"post":          {url: "https://bsky.app/profile/gopher.dev/post/3kabc", want: blueskyRef{actor: "gopher.dev", rkey: "3kabc"}, wantOK: true},
+		"post with did": {url: "https://bsky.app/profile/did:plc:xyz/post/3kabc", want: blueskyRef{actor: "did:plc:xyz", rkey: "3kabc"}, wantOK: true},
+		"profile":       {url: "https://bsky.app/profile/gopher.dev/", want: blueskyRef{actor: "gopher.dev"}, wantOK: true},
+		"followers":     {url: "https://bsky.app/profile/gopher.dev/followers"},
+		"feed":          {url: "https://bsky.app/"},
+		"other host":    {url: "https://example.com/profile/gopher.dev/post/3kabc"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseBlueskyURL(tc.url)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("parseBlueskyURL(%q) = %+v, %v, want %+v, %v", tc.url, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetch_Bluesky(t *testing.T) {
+	author := `{"did": "did:plc:xyz", "handle": "gopher.dev", "displayName": "Gopher", "avatar": "https://cdn.example/avatar.jpg"}`
+	responses := map[string]string{
+		"/xrpc/com.atproto.identity.resolveHandle?handle=gopher.dev": `{"did": "did:plc:xyz"}`,
+		"/xrpc/app.bsky.actor.getProfile?actor=gopher.dev": `{"did": "did:plc:xyz", "handle": "gopher.dev",
+			"displayName": "Gopher", "description": "I like Go.", "avatar": "https://cdn.example/avatar.jpg"}`,
+		"/xrpc/app.bsky.feed.getPostThread?depth=0&parentHeight=0&uri=at%3A%2F%2Fdid%3Aplc%3Axyz%2Fapp.bsky.feed.post%2Fimages": `{"thread": {"post": {
+			"author": ` + author + `, "record": {"text": "Look at this", "createdAt": "2024-03-01T12:00:00.000Z"},
+			"embed": {"$type": "app.bsky.embed.images#view", "images": [{"thumb": "https://cdn.example/thumb.jpg",
+				"fullsize": "https://cdn.example/full.jpg", "alt": "A gopher", "aspectRatio": {"width": 800, "height": 600}}]},
+			"replyCount": 1, "repostCount": 2, "likeCount": 3}}}`,
+		"/xrpc/app.bsky.feed.getPostThread?depth=0&parentHeight=0&uri=at%3A%2F%2Fdid%3Aplc%3Axyz%2Fapp.bsky.feed.post%2Flink": `{"thread": {"post": {
+			"author": ` + author + `, "record": {"text": "Read this", "createdAt": "2024-03-02T12:00:00Z"},
+			"embed": {"$type": "app.bsky.embed.external#view", "external": {"uri": "https://go.dev/blog",
+				"title": "The Go Blog", "description": "Posts", "thumb": "https://cdn.example/card.jpg"}}}}}`,
+	}
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if body, ok := responses[strings.TrimPrefix(req.URL.String(), "http://stub")]; ok {
+				return []byte(body), 200, nil
+			}
+			return nil, 404, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithEndpoint(EndpointBlueskyAPI, "http://stub/"))
+
+	tests := map[string]struct {
+		url       string
+		wantTitle string
+		wantDesc  string
+		wantImage string
+		wantPost  *Post
+	}{
+		"post with images": {
+			url:       "https://bsky.app/profile/gopher.dev/post/images",
+			wantTitle: "Gopher (@gopher.dev)",
+			wantDesc:  "Look at this",
+			wantImage: "https://cdn.example/full.jpg",
+			wantPost: &Post{Author: "Gopher", Handle: "@gopher.dev", AuthorURL: "https://bsky.app/profile/gopher.dev",
+				Text: "Look at this", Created: "2024-03-01T12:00:00Z", Replies: 1, Reposts: 2, Likes: 3,
+				Media: []Media{{Type: MediaImage, URL: "https://cdn.example/full.jpg", PreviewURL: "https://cdn.example/thumb.jpg",
+					Width: 800, Height: 600, Alt: "A gopher"}}},
+		},
+		"post with link card": {
+			url:       "https://bsky.app/profile/did:plc:xyz/post/link",
+			wantTitle: "Gopher (@gopher.dev)",
+			wantDesc:  "Read this",
+			wantImage: "https://cdn.example/card.jpg",
+			wantPost: &Post{Author: "Gopher", Handle: "@gopher.dev", AuthorURL: "https://bsky.app/profile/gopher.dev",
+				Text: "Read this", Created: "2024-03-02T12:00:00Z",
+				Linked: &Result{URL: "https://go.dev/blog", Title: "The Go Blog", Description: "Posts", Image: "https://cdn.example/card.jpg",
+					Sources: map[string]Source{FieldTitle: SourceAPI, FieldDescription: SourceAPI, FieldImage: SourceAPI}}},
+		},
+		"profile": {
+			url:       "https://bsky.app/profile/gopher.dev",
+			wantTitle: "Gopher (@gopher.dev)",
+			wantDesc:  "I like Go.",
+			wantImage: "https://cdn.example/avatar.jpg",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := fetcher.Fetch(t.Context(), tc.url)
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			if result.Title != tc.wantTitle {
+				t.Errorf("got title %q, want %q", result.Title, tc.wantTitle)
+			}
+			if result.Description != tc.wantDesc {
+				t.Errorf("got description %q, want %q", result.Description, tc.wantDesc)
+			}
+			if result.Image != tc.wantImage {
+				t.Errorf("got image %q, want %q", result.Image, tc.wantImage)
+			}
+			if result.SiteName != "Bluesky" || result.Sources[FieldTitle] != SourceAPI {
+				t.Errorf("got site name %q and title source %q", result.SiteName, result.Sources[FieldTitle])
+			}
+			if !reflect.DeepEqual(result.Post, tc.wantPost) {
+				t.Errorf("got post %+v, want %+v", result.Post, tc.wantPost)
+			}
+		})
+	}
+}
+
+func TestFetch_BlueskyFallback(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if strings.Contains(req.URL.Path, "/xrpc/") {
+				return []byte(`{"error": "InvalidRequest"}`), 400, nil
+			}
+			return []byte(`<html><head><title>Bluesky</title></head></html>`), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://bsky.app/profile/unknown.example/post/3kabc")
+
+	if result.Title != "Bluesky" {
+		t.Errorf("got title %q, want %q", result.Title, "Bluesky")
+	}
+	if result.Post != nil {
+		t.Errorf("got post %+v, want nil", result.Post)
+	}
+}
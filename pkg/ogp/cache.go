@@ -0,0 +1,64 @@
+package ogp
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache is a cache of at most size entries that expire after ttl. When it
+// is full, expired entries are dropped first, then the oldest one.
+type ttlCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]ttlEntry[V]
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+func newTTLCache[V any](size int, ttl time.Duration) *ttlCache[V] {
+	return &ttlCache[V]{size: size, ttl: ttl, now: time.Now, entries: make(map[string]ttlEntry[V])}
+}
+
+// Get returns the value of key unless it is missing or expired.
+func (c *ttlCache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expires) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores value for key, evicting an entry when the cache is full.
+func (c *ttlCache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict(now)
+	}
+	c.entries[key] = ttlEntry[V]{value: value, expires: now.Add(c.ttl)}
+}
+
+func (c *ttlCache[V]) evict(now time.Time) {
+	var oldest string
+	for key, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldest == "" || e.expires.Before(c.entries[oldest].expires) {
+			oldest = key
+		}
+	}
+	if len(c.entries) >= c.size {
+		delete(c.entries, oldest)
+	}
+}
@@ -0,0 +1,33 @@
+package ogp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := newTTLCache[int](2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	now = now.Add(time.Second)
+	c.Set("b", 2)
+	now = now.Add(time.Second)
+	c.Set("c", 3)
+	if _, ok := c.Get("a"); ok {
+		t.Error("got a, want the oldest entry evicted")
+	}
+	if v, ok := c.Get("b"); !ok || v != 2 {
+		t.Errorf("got b = %d, %v, want 2", v, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("c"); ok {
+		t.Error("got c, want it expired")
+	}
+	c.Set("d", 4)
+	if len(c.entries) != 1 {
+		t.Errorf("got %d entries, want the expired ones dropped", len(c.entries))
+	}
+}
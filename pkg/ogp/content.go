@@ -0,0 +1,503 @@
+package ogp
+
+import (
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// minParagraphLength is the shortest paragraph, in runes, that counts
+	// towards the score of its ancestors.
+	minParagraphLength = 25
+	// minLeadLength is the shortest paragraph, in runes, preferred as the lead.
+	minLeadLength = 50
+	// wordsPerMinute and cjkCharsPerMinute are average silent reading speeds
+	// for space-separated scripts and for Chinese/Japanese text.
+	wordsPerMinute    = 200
+	cjkCharsPerMinute = 500
+)
+
+// Content is the main body of a page, found by scoring the document tree.
+type Content struct {
+	Text string `json:"text"`
+	// HTML is the sanitized body, only set when requested.
+	HTML string `json:"html,omitempty"`
+	// Lead is the first substantial paragraph.
+	Lead      string `json:"lead,omitempty"`
+	WordCount int    `json:"word_count"`
+	// ReadingTime is the estimated reading time in minutes.
+	ReadingTime int `json:"reading_time"`
+}
+
+// WithContentExtraction controls whether the main content of general pages
+// is extracted. Its lead paragraph is used when the page has no description.
+func WithContentExtraction(enabled bool) FetcherOption {
+	return func(f *Fetcher) { f.extractContent = enabled }
+}
+
+// WithContentHTML controls whether extracted content also includes
+// sanitized HTML.
+func WithContentHTML(enabled bool) FetcherOption {
+	return func(f *Fetcher) { f.contentHTML = enabled }
+}
+
+var (
+	unlikelyContentPattern = regexp.MustCompile(`(?i)banner|breadcrumb|comment|community|cookie|disqus|footer|header|menu|modal|nav|popup|promo|related|remark|share|sidebar|social|sponsor|subscribe|advert`)
+	positiveContentPattern = regexp.MustCompile(`(?i)article|body|content|entry|main|page|post|story|text|blog`)
+	negativeContentPattern = regexp.MustCompile(`(?i)comment|footer|footnote|meta|related|sidebar|sponsor|widget|share|social|promo`)
+)
+
+// skippedContentTags never contain main content.
+var skippedContentTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+	"nav": true, "header": true, "footer": true, "aside": true,
+	"form": true, "button": true, "input": true, "select": true, "textarea": true,
+	"iframe": true, "object": true, "embed": true, "svg": true, "canvas": true,
+}
+
+// blockTags break text into paragraphs.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "blockquote": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "figcaption": true, "figure": true, "h1": true,
+	"h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "hr": true,
+	"li": true, "main": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "td": true, "th": true, "tr": true, "ul": true,
+}
+
+// sanitizedTags are kept in content HTML; other elements are unwrapped.
+var sanitizedTags = map[string]bool{
+	"a": true, "b": true, "blockquote": true, "br": true, "code": true, "em": true,
+	"figcaption": true, "figure": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "hr": true, "i": true, "img": true,
+	"li": true, "ol": true, "p": true, "pre": true, "strong": true, "table": true,
+	"tbody": true, "td": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+// extractContent finds the main content of doc. It returns nil when the
+// document has no text worth extracting.
+func extractContent(doc *html.Node, baseURL string, withHTML bool) *Content {
+	nodes := contentNodes(doc)
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var blocks []contentBlock
+	for _, n := range nodes {
+		blocks = collectBlocks(n, blocks)
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	texts := make([]string, len(blocks))
+	for i, b := range blocks {
+		texts[i] = b.text
+	}
+	content := &Content{
+		Text: strings.Join(texts, "\n\n"),
+		Lead: leadParagraph(blocks),
+	}
+	content.WordCount, content.ReadingTime = countWords(content.Text)
+	if withHTML {
+		var sb strings.Builder
+		for _, n := range nodes {
+			writeSanitized(&sb, n, baseURL)
+		}
+		content.HTML = sb.String()
+	}
+	return content
+}
+
+// contentNodes returns the best scoring element and its siblings that look
+// like part of the same content.
+func contentNodes(doc *html.Node) []*html.Node {
+	scores := make(map[*html.Node]float64)
+	scoreParagraphs(doc, scores)
+
+	// candidates are visited in document order so the first one wins ties
+	var best *html.Node
+	for _, n := range scoredNodes(doc, scores, nil) {
+		score := scores[n] * (1 - linkDensity(n))
+		scores[n] = score
+		if best == nil || score > scores[best] {
+			best = n
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	if best.Parent == nil {
+		return []*html.Node{best}
+	}
+
+	threshold := math.Max(10, scores[best]*0.2)
+	var nodes []*html.Node
+	for s := best.Parent.FirstChild; s != nil; s = s.NextSibling {
+		if s == best {
+			nodes = append(nodes, s)
+			continue
+		}
+		if s.Type != html.ElementNode {
+			continue
+		}
+		if score, ok := scores[s]; ok && score >= threshold {
+			nodes = append(nodes, s)
+			continue
+		}
+		if s.Data == "p" && utf8.RuneCountInString(nodeText(s)) > 80 && linkDensity(s) < 0.25 {
+			nodes = append(nodes, s)
+		}
+	}
+	return nodes
+}
+
+// scoreParagraphs adds the score of every paragraph to its parent and half of
+// it to its grandparent.
+func scoreParagraphs(n *html.Node, scores map[*html.Node]float64) {
+	if n.Type == html.ElementNode {
+		if skipContentNode(n) {
+			return
+		}
+		if isParagraph(n) {
+			text := nodeText(n)
+			length := utf8.RuneCountInString(text)
+			if length >= minParagraphLength {
+				score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "、")+strings.Count(text, "，"))
+				score += math.Min(float64(length/100), 3)
+				addContentScore(n.Parent, score, scores)
+				if n.Parent != nil {
+					addContentScore(n.Parent.Parent, score/2, scores)
+				}
+			}
+			return
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		scoreParagraphs(c, scores)
+	}
+}
+
+// scoredNodes appends the scored nodes under n to nodes in document order.
+func scoredNodes(n *html.Node, scores map[*html.Node]float64, nodes []*html.Node) []*html.Node {
+	if _, ok := scores[n]; ok {
+		nodes = append(nodes, n)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		nodes = scoredNodes(c, scores, nodes)
+	}
+	return nodes
+}
+
+func addContentScore(n *html.Node, score float64, scores map[*html.Node]float64) {
+	if n == nil || n.Type != html.ElementNode {
+		return
+	}
+	if _, ok := scores[n]; !ok {
+		scores[n] = initialContentScore(n)
+	}
+	scores[n] += score
+}
+
+// initialContentScore weighs a candidate by its tag, class and id.
+func initialContentScore(n *html.Node) float64 {
+	var score float64
+	switch n.Data {
+	case "article":
+		score = 10
+	case "div", "main", "section":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+	for _, attr := range []string{getAttr(n, "class"), getAttr(n, "id")} {
+		if attr == "" {
+			continue
+		}
+		if negativeContentPattern.MatchString(attr) {
+			score -= 25
+		}
+		if positiveContentPattern.MatchString(attr) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// isParagraph reports whether n is a paragraph, or a div holding only inline content.
+func isParagraph(n *html.Node) bool {
+	switch n.Data {
+	case "p", "pre":
+		return true
+	case "div":
+		return !hasBlockChild(n)
+	}
+	return false
+}
+
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && blockTags[c.Data] {
+			return true
+		}
+	}
+	return false
+}
+
+// skipContentNode reports whether n and its children are never content.
+func skipContentNode(n *html.Node) bool {
+	if skippedContentTags[n.Data] {
+		return true
+	}
+	switch n.Data {
+	case "html", "body", "article", "main":
+		return false
+	}
+	names := getAttr(n, "class") + " " + getAttr(n, "id")
+	return unlikelyContentPattern.MatchString(names) && !positiveContentPattern.MatchString(names)
+}
+
+// linkDensity is the share of the text of n that is inside links.
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(nodeText(n))
+	if total == 0 {
+		return 0
+	}
+	var links int
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			links += utf8.RuneCountInString(nodeText(n))
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return float64(links) / float64(total)
+}
+
+// nodeText returns the whitespace-collapsed text of n, skipping non-content elements.
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			sb.WriteString(n.Data)
+		case html.ElementNode:
+			if skippedContentTags[n.Data] {
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return collapseSpace(sb.String())
+}
+
+type contentBlock struct {
+	tag  string
+	text string
+}
+
+// collectBlocks appends the paragraphs of n to blocks.
+func collectBlocks(n *html.Node, blocks []contentBlock) []contentBlock {
+	var sb strings.Builder
+	tag := n.Data
+	flush := func() {
+		if text := collapseSpace(sb.String()); text != "" {
+			blocks = append(blocks, contentBlock{tag: tag, text: text})
+		}
+		sb.Reset()
+	}
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		switch c.Type {
+		case html.TextNode:
+			sb.WriteString(c.Data)
+			return
+		case html.ElementNode:
+			if skipContentNode(c) {
+				return
+			}
+			if c.Data == "br" {
+				sb.WriteString(" ")
+				return
+			}
+			if blockTags[c.Data] {
+				flush()
+				outer := tag
+				tag = c.Data
+				for gc := c.FirstChild; gc != nil; gc = gc.NextSibling {
+					walk(gc)
+				}
+				flush()
+				tag = outer
+				return
+			}
+		}
+		for gc := c.FirstChild; gc != nil; gc = gc.NextSibling {
+			walk(gc)
+		}
+	}
+	walk(n)
+	flush()
+	return blocks
+}
+
+// leadParagraph returns the first substantial paragraph, or the first
+// paragraph when none is long enough.
+func leadParagraph(blocks []contentBlock) string {
+	var first string
+	for _, b := range blocks {
+		if b.tag != "p" && b.tag != "div" {
+			continue
+		}
+		if utf8.RuneCountInString(b.text) >= minLeadLength {
+			return b.text
+		}
+		if first == "" {
+			first = b.text
+		}
+	}
+	return first
+}
+
+// writeSanitized writes n as HTML keeping only structural and inline
+// formatting elements, links and images with absolute http(s) URLs.
+func writeSanitized(sb *strings.Builder, n *html.Node, baseURL string) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			writeSanitized(sb, c, baseURL)
+		}
+		return
+	}
+	if skipContentNode(n) {
+		return
+	}
+
+	tag := n.Data
+	var attrs string
+	switch tag {
+	case "img":
+		src := safeContentURL(baseURL, getAttr(n, "src"))
+		if src == "" {
+			return
+		}
+		attrs = ` src="` + html.EscapeString(src) + `"`
+		if alt := getAttr(n, "alt"); alt != "" {
+			attrs += ` alt="` + html.EscapeString(alt) + `"`
+		}
+	case "a":
+		if href := safeContentURL(baseURL, getAttr(n, "href")); href != "" {
+			attrs = ` href="` + html.EscapeString(href) + `"`
+		} else {
+			tag = ""
+		}
+	case "div", "section", "article", "main":
+		// inline-only containers become paragraphs
+		tag = ""
+		if !hasBlockChild(n) {
+			tag = "p"
+		}
+	default:
+		if !sanitizedTags[tag] {
+			tag = ""
+		}
+	}
+
+	if tag == "" {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			writeSanitized(sb, c, baseURL)
+		}
+		return
+	}
+	sb.WriteString("<" + tag + attrs + ">")
+	switch tag {
+	case "img", "br", "hr":
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeSanitized(sb, c, baseURL)
+	}
+	sb.WriteString("</" + tag + ">")
+}
+
+func safeContentURL(baseURL, href string) string {
+	resolved := ResolveURL(baseURL, strings.TrimSpace(href))
+	u, err := url.Parse(resolved)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return resolved
+}
+
+// isCJK reports whether r is written without spaces between words.
+// Hangul is excluded: Korean separates words with spaces.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// collapseSpace collapses runs of whitespace into one space, dropping it
+// entirely between two CJK characters, where source line breaks are not spaces.
+func collapseSpace(s string) string {
+	fields := strings.Fields(s)
+	var sb strings.Builder
+	for i, f := range fields {
+		if i > 0 {
+			prev, _ := utf8.DecodeLastRuneInString(fields[i-1])
+			next, _ := utf8.DecodeRuneInString(f)
+			if !isCJK(prev) || !isCJK(next) {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(f)
+	}
+	return sb.String()
+}
+
+// countWords returns the word count and reading time in minutes of text.
+// Each Chinese or Japanese character counts as a word and is read at
+// cjkCharsPerMinute; other scripts are split on spaces and punctuation.
+func countWords(text string) (words, minutes int) {
+	var latin, cjk int
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			cjk++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' || r == '’':
+			if !inWord {
+				latin++
+				inWord = true
+			}
+		default:
+			inWord = false
+		}
+	}
+	words = latin + cjk
+	if words == 0 {
+		return 0, 0
+	}
+	minutes = int(math.Ceil(float64(latin)/wordsPerMinute + float64(cjk)/cjkCharsPerMinute))
+	return words, max(minutes, 1)
+}
@@ -0,0 +1,174 @@
+package ogp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const articlePage = `<html><head><title>Post</title></head><body>
+	<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+	<div class="sidebar"><p>Subscribe to our newsletter, it is great, really, truly great.</p></div>
+	<article class="post-content">
+		<h1>Post title</h1>
+		<p>Short intro.</p>
+		<p>The first real paragraph explains, at some length, what this article is about and why it matters.</p>
+		<p>A second paragraph continues the story <a href="/more">with a link</a>, adding detail, context and more words.</p>
+		<script>var tracking = true;</script>
+		<img src="/figure.png" alt="Figure" onerror="alert(1)">
+		<p><a href="javascript:alert(1)">Unsafe link</a> in a paragraph that is long enough to be scored as content.</p>
+	</article>
+	<footer><p>Copyright notice, all rights reserved, no part may be reproduced.</p></footer>
+</body></html>`
+
+func parseTestHTML(t *testing.T, s string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("failed to parse HTML: %v", err)
+	}
+	return doc
+}
+
+func TestExtractContent(t *testing.T) {
+	content := extractContent(parseTestHTML(t, articlePage), "https://example.com/post", true)
+	if content == nil {
+		t.Fatal("got nil content")
+	}
+
+	for _, want := range []string{"Post title", "The first real paragraph", "with a link", "Unsafe link"} {
+		if !strings.Contains(content.Text, want) {
+			t.Errorf("text %q does not contain %q", content.Text, want)
+		}
+	}
+	for _, unwanted := range []string{"Home", "Subscribe", "Copyright", "tracking"} {
+		if strings.Contains(content.Text, unwanted) {
+			t.Errorf("text %q contains %q", content.Text, unwanted)
+		}
+	}
+
+	wantLead := "The first real paragraph explains, at some length, what this article is about and why it matters."
+	if content.Lead != wantLead {
+		t.Errorf("got lead %q, want %q", content.Lead, wantLead)
+	}
+
+	for _, want := range []string{`<h1>Post title</h1>`, `<a href="https://example.com/more">with a link</a>`, `<img src="https://example.com/figure.png" alt="Figure">`} {
+		if !strings.Contains(content.HTML, want) {
+			t.Errorf("html %q does not contain %q", content.HTML, want)
+		}
+	}
+	for _, unwanted := range []string{"<script", "javascript:", "onerror", "class="} {
+		if strings.Contains(content.HTML, unwanted) {
+			t.Errorf("html %q contains %q", content.HTML, unwanted)
+		}
+	}
+	if content.WordCount == 0 || content.ReadingTime != 1 {
+		t.Errorf("got %d words in %d minutes, want some words in 1 minute", content.WordCount, content.ReadingTime)
+	}
+}
+
+func TestExtractContent_WithoutHTML(t *testing.T) {
+	content := extractContent(parseTestHTML(t, articlePage), "https://example.com/post", false)
+	if content == nil {
+		t.Fatal("got nil content")
+	}
+	if content.HTML != "" {
+		t.Errorf("got html %q, want empty", content.HTML)
+	}
+}
+
+func TestExtractContent_NoText(t *testing.T) {
+	doc := parseTestHTML(t, `<html><body><nav><a href="/">Home</a></nav></body></html>`)
+	if content := extractContent(doc, "https://example.com", false); content != nil {
+		t.Errorf("got content %+v, want nil", content)
+	}
+}
+
+func TestExtractContent_TiesPickFirstInDocument(t *testing.T) {
+	doc := parseTestHTML(t, `<html><body>
+		<div><div><p>The first block of text, long enough to be scored as the page content.</p></div></div>
+		<div><div><p>The other block of text, long enough to be scored as the page content.</p></div></div>
+	</body></html>`)
+
+	for range 20 {
+		content := extractContent(doc, "https://example.com", false)
+		if content == nil || !strings.HasPrefix(content.Text, "The first block") || strings.Contains(content.Text, "other block") {
+			t.Fatalf("got content %+v, want the first block", content)
+		}
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	tests := map[string]struct {
+		text        string
+		wantWords   int
+		wantMinutes int
+	}{
+		"empty":    {text: "", wantWords: 0, wantMinutes: 0},
+		"english":  {text: "It's a short, simple sentence.", wantWords: 5, wantMinutes: 1},
+		"japanese": {text: "これは日本語の文章です。", wantWords: 11, wantMinutes: 1},
+		"mixed":    {text: "Go言語 is fun", wantWords: 5, wantMinutes: 1},
+		"korean":   {text: "한국어 문장 입니다", wantWords: 3, wantMinutes: 1},
+		"long english": {
+			text:        strings.Repeat("word ", 401),
+			wantWords:   401,
+			wantMinutes: 3,
+		},
+		"long japanese": {
+			text:        strings.Repeat("語", 1001),
+			wantWords:   1001,
+			wantMinutes: 3,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			words, minutes := countWords(tc.text)
+			if words != tc.wantWords || minutes != tc.wantMinutes {
+				t.Errorf("countWords(%q) = %d, %d, want %d, %d", tc.text, words, minutes, tc.wantWords, tc.wantMinutes)
+			}
+		})
+	}
+}
+
+func TestCollapseSpace(t *testing.T) {
+	tests := map[string]struct {
+		text string
+		want string
+	}{
+		"latin":           {text: "  hello \n  world ", want: "hello world"},
+		"cjk line break":  {text: "日本語の\n  文章", want: "日本語の文章"},
+		"cjk and latin":   {text: "Go\n言語", want: "Go 言語"},
+		"only whitespace": {text: " \n\t ", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := collapseSpace(tc.text); got != tc.want {
+				t.Errorf("collapseSpace(%q) = %q, want %q", tc.text, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_ContentExtraction_LeadAsDescription(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			return []byte(articlePage), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithContentExtraction(true))
+	result := fetcher.Fetch(t.Context(), "https://example.com/post")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.Content == nil {
+		t.Fatal("got nil content")
+	}
+	if result.Description != result.Content.Lead {
+		t.Errorf("got description %q, want lead %q", result.Description, result.Content.Lead)
+	}
+}
@@ -0,0 +1,74 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const cratesSiteName = "crates.io"
+
+type cratesResponse struct {
+	Crate struct {
+		Name             string `json:"name"`
+		Description      string `json:"description"`
+		MaxStableVersion string `json:"max_stable_version"`
+		MaxVersion       string `json:"max_version"`
+		Repository       string `json:"repository"`
+	} `json:"crate"`
+	Versions []struct {
+		Num       string `json:"num"`
+		License   string `json:"license"`
+		CreatedAt string `json:"created_at"`
+	} `json:"versions"`
+}
+
+// IsCratesURL checks if the URL is a crates.io crate page.
+func IsCratesURL(targetURL string) bool {
+	_, ok := crateName(targetURL)
+	return ok
+}
+
+// crateName returns the crate name of /crates/<name>[/<version>] URLs.
+func crateName(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Hostname() != "crates.io" {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "crates" || segments[1] == "" {
+		return "", false
+	}
+	return segments[1], true
+}
+
+func (f *Fetcher) fetchCrate(ctx context.Context, targetURL string) *Result {
+	name, _ := crateName(targetURL)
+
+	var resp cratesResponse
+	if err := f.fetchJSON(ctx, f.endpoint(EndpointCratesAPI)+"/crates/"+url.PathEscape(name), &resp, nil); err != nil {
+		log.Warnf("crates.io API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	crate := resp.Crate
+
+	pkg := &Package{
+		Registry:    RegistryCrates,
+		Name:        crate.Name,
+		Version:     crate.MaxStableVersion,
+		Description: strings.TrimSpace(crate.Description),
+		Repository:  normalizeRepositoryURL(crate.Repository),
+	}
+	if pkg.Version == "" {
+		pkg.Version = crate.MaxVersion
+	}
+	for _, v := range resp.Versions {
+		if v.Num == pkg.Version {
+			pkg.License, pkg.Published = v.License, normalizeTime(v.CreatedAt)
+			break
+		}
+	}
+	return packageResult(targetURL, cratesSiteName, pkg, f.pageImage(ctx, targetURL))
+}
@@ -0,0 +1,129 @@
+package ogp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Endpoint identifies an external API used by a provider. Its base URL can
+// be overridden with WithEndpoint, e.g. for a self-hosted instance or a
+// local stub.
+type Endpoint string
+
+// Provider endpoints.
+const (
+	EndpointTwitterOEmbed      Endpoint = "twitter_oembed"
+	EndpointTwitterSyndication Endpoint = "twitter_syndication"
+	EndpointYouTubeOEmbed      Endpoint = "youtube_oembed"
+	EndpointYouTubeWatch       Endpoint = "youtube_watch"
+	EndpointYouTubeThumbnail   Endpoint = "youtube_thumbnail"
+	EndpointGitHubAPI          Endpoint = "github_api"
+	EndpointBlueskyAPI         Endpoint = "bluesky_api"
+	EndpointWikipediaAPI       Endpoint = "wikipedia_api"
+	EndpointQiitaAPI           Endpoint = "qiita_api"
+	EndpointZennAPI            Endpoint = "zenn_api"
+	EndpointNoteAPI            Endpoint = "note_api"
+	EndpointGoProxy            Endpoint = "go_proxy"
+	EndpointNPMRegistry        Endpoint = "npm_registry"
+	EndpointPyPIAPI            Endpoint = "pypi_api"
+	EndpointCratesAPI          Endpoint = "crates_api"
+	EndpointRedditAPI          Endpoint = "reddit_api"
+	EndpointHackerNewsAPI      Endpoint = "hackernews_api"
+)
+
+var defaultEndpoints = map[Endpoint]string{
+	EndpointTwitterOEmbed:      "https://publish.twitter.com/oembed",
+	EndpointTwitterSyndication: "https://cdn.syndication.twimg.com/tweet-result",
+	EndpointYouTubeOEmbed:      "https://www.youtube.com/oembed",
+	EndpointYouTubeWatch:       "https://www.youtube.com/watch",
+	EndpointYouTubeThumbnail:   "https://i.ytimg.com/vi",
+	EndpointGitHubAPI:          "https://api.github.com",
+	EndpointBlueskyAPI:         "https://public.api.bsky.app",
+	EndpointWikipediaAPI:       "https://{host}/api/rest_v1",
+	EndpointQiitaAPI:           "https://qiita.com/api/v2",
+	EndpointZennAPI:            "https://zenn.dev/api",
+	EndpointNoteAPI:            "https://note.com/api",
+	EndpointGoProxy:            "https://proxy.golang.org",
+	EndpointNPMRegistry:        "https://registry.npmjs.org",
+	EndpointPyPIAPI:            "https://pypi.org/pypi",
+	EndpointCratesAPI:          "https://crates.io/api/v1",
+	EndpointRedditAPI:          "https://www.reddit.com",
+	EndpointHackerNewsAPI:      "https://hacker-news.firebaseio.com/v0",
+}
+
+// WithEndpoint overrides the base URL of a provider endpoint. For
+// EndpointWikipediaAPI, {host} in the URL is replaced by the wiki host.
+func WithEndpoint(e Endpoint, baseURL string) FetcherOption {
+	return func(f *Fetcher) {
+		if f.endpoints == nil {
+			f.endpoints = make(map[Endpoint]string)
+		}
+		f.endpoints[e] = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// Endpoints returns the names of all provider endpoints.
+func Endpoints() []Endpoint {
+	endpoints := make([]Endpoint, 0, len(defaultEndpoints))
+	for e := range defaultEndpoints {
+		endpoints = append(endpoints, e)
+	}
+	slices.Sort(endpoints)
+	return endpoints
+}
+
+// endpoint returns the base URL of e.
+func (f *Fetcher) endpoint(e Endpoint) string {
+	if u, ok := f.endpoints[e]; ok {
+		return u
+	}
+	return defaultEndpoints[e]
+}
+
+// errFetchFailed wraps the errors of requests that got no response.
+var errFetchFailed = errors.New("failed to fetch")
+
+// fetchJSON requests reqURL and decodes its JSON response into v.
+func (f *Fetcher) fetchJSON(ctx context.Context, reqURL string, v any, header http.Header) error {
+	return f.fetchJSONLimit(ctx, reqURL, v, header, 0)
+}
+
+// fetchJSONLimit is fetchJSON for responses that can be large. It fails
+// without decoding when the response is over limit bytes; 0 means no limit.
+func (f *Fetcher) fetchJSONLimit(ctx context.Context, reqURL string, v any, header http.Header, limit int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+	req.Header.Set("Accept", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	var body []byte
+	var statusCode int
+	if limit > 0 {
+		body, statusCode, err = f.requestPrefix(req, limit+1)
+	} else {
+		body, statusCode, err = f.client.Request(req)
+	}
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", errFetchFailed, reqURL, err)
+	}
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d for %s", statusCode, reqURL)
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return fmt.Errorf("response of %s is over %d bytes", reqURL, limit)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", reqURL, err)
+	}
+	return nil
+}
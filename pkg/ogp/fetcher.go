@@ -2,11 +2,12 @@ package ogp
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/dyatlov/go-opengraph/opengraph"
-	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
 )
 
 // HTTPClient is the interface for making HTTP requests and returning the response body.
@@ -14,26 +15,94 @@ type HTTPClient interface {
 	Request(req *http.Request) (body []byte, statusCode int, err error)
 }
 
+// PrefixHTTPClient is an HTTPClient that can stop reading a response body
+// after its first n bytes. Fetcher uses it for pages it only needs the start
+// of; other clients read the whole body.
+type PrefixHTTPClient interface {
+	HTTPClient
+	RequestPrefix(req *http.Request, n int64) (body []byte, statusCode int, err error)
+}
+
 // Fetcher fetches OGP metadata from URLs.
 type Fetcher struct {
-	client HTTPClient
+	client            HTTPClient
+	probeImages       bool
+	imagePlaceholders bool
+	iconSize          int
+	extractContent    bool
+	contentHTML       bool
+	policy            Policy
+	domainPolicies    map[string]Policy
+	rules             map[string]*compiledRules
+	endpoints         map[Endpoint]string
+	githubToken       string
+	githubHosts       map[string]githubInstance
+	linkedPreviews    bool
+	// mastodonInstances caches instance probes by origin.
+	mastodonInstances *ttlCache[*mastodonInstance]
+}
+
+// FetcherOption applies a configuration to a Fetcher.
+type FetcherOption func(*Fetcher)
+
+// WithImageProbe controls whether candidate images are fetched to validate
+// them and choose the best preview image.
+func WithImageProbe(enabled bool) FetcherOption {
+	return func(f *Fetcher) { f.probeImages = enabled }
 }
 
 // NewFetcher creates a new Fetcher with the given HTTP client.
-func NewFetcher(client HTTPClient) *Fetcher {
-	return &Fetcher{client: client}
+func NewFetcher(client HTTPClient, opts ...FetcherOption) *Fetcher {
+	f := &Fetcher{
+		client:            client,
+		iconSize:          defaultIconSize,
+		mastodonInstances: newTTLCache[*mastodonInstance](mastodonInstanceCacheSize, mastodonInstanceTTL),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 // Fetch fetches OGP metadata from a URL.
-func (f *Fetcher) Fetch(targetURL string) *Result {
-	if IsTwitterURL(targetURL) {
-		return f.fetchTwitter(targetURL)
+func (f *Fetcher) Fetch(ctx context.Context, targetURL string) *Result {
+	var result *Result
+	if p := f.providerFor(ctx, targetURL); p != nil {
+		result = p.fetch(f, ctx, targetURL)
+	} else {
+		result = f.fetchGeneral(ctx, targetURL)
 	}
-	return f.fetchGeneral(targetURL)
+	if result.Err != nil {
+		return result
+	}
+	if f.imagePlaceholders {
+		f.applyImagePlaceholders(ctx, result)
+	}
+	result.Score = completenessScore(result)
+	return result
 }
 
-func (f *Fetcher) fetchGeneral(targetURL string) *Result {
-	req, err := http.NewRequest(http.MethodGet, targetURL, nil)
+// requestPrefix sends req and returns at most the first n bytes of the body.
+func (f *Fetcher) requestPrefix(req *http.Request, n int64) ([]byte, int, error) {
+	if c, ok := f.client.(PrefixHTTPClient); ok {
+		return c.RequestPrefix(req, n)
+	}
+	body, statusCode, err := f.client.Request(req)
+	if int64(len(body)) > n {
+		body = body[:n]
+	}
+	return body, statusCode, err
+}
+
+func (f *Fetcher) fetchGeneral(ctx context.Context, targetURL string) *Result {
+	return f.fetchPage(ctx, targetURL, nil)
+}
+
+// fetchPage fetches metadata from the HTML of a page. Providers reading
+// site-specific markup pass extract, called with the parsed document before
+// the field values are chosen.
+func (f *Fetcher) fetchPage(ctx context.Context, targetURL string, extract func(doc *html.Node, fallback *HTMLFallbackData)) *Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
 	if err != nil {
 		return &Result{URL: targetURL, Err: fmt.Errorf("failed to create request for %s: %w", targetURL, err)}
 	}
@@ -52,37 +121,69 @@ func (f *Fetcher) fetchGeneral(targetURL string) *Result {
 		return &Result{URL: targetURL, Err: fmt.Errorf("failed to process HTML for %s: %w", targetURL, err)}
 	}
 
-	result := &Result{
-		URL:         targetURL,
-		Title:       og.Title,
-		Description: og.Description,
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return &Result{URL: targetURL, Err: fmt.Errorf("failed to parse HTML for %s: %w", targetURL, err)}
 	}
+	fallback := extractHTMLFallback(doc, targetURL)
+	fallback.addCandidate(FieldTitle, SourceOG, og.Title)
+	fallback.addCandidate(FieldDescription, SourceOG, og.Description)
 	if len(og.Images) > 0 {
-		result.Image = og.Images[0].URL
+		fallback.addCandidate(FieldImage, SourceOG, og.Images[0].URL)
+	}
+
+	result := &Result{URL: targetURL, SiteName: og.SiteName}
+	result.recordSources(SourceOG)
+	if rules := f.rulesFor(targetURL); rules != nil {
+		result.Extra = applyRules(doc, fallback, rules, targetURL)
+	}
+	if f.extractContent {
+		result.Content = extractContent(doc, targetURL, f.contentHTML)
+		if result.Content != nil {
+			fallback.addCandidate(FieldDescription, SourceContent, result.Content.Lead)
+		}
 	}
 
-	applyFallback(result, body, targetURL)
+	if extract != nil {
+		extract(doc, fallback)
+	}
+
+	order := f.sourceOrder(targetURL)
+	applyPolicy(result, fallback, order)
+	if f.probeImages {
+		f.selectImage(ctx, result, og, fallback, targetURL, order[FieldImage])
+	}
+	f.applyIcons(ctx, result, fallback, targetURL)
 	return result
 }
 
-func applyFallback(result *Result, htmlContent []byte, targetURL string) {
-	if result.Title != "" && result.Description != "" && result.Image != "" {
-		return
+// applyPolicy fills the policy fields of result from the first source in
+// order that has a value.
+func applyPolicy(result *Result, fallback *HTMLFallbackData, order map[string][]Source) {
+	fields := map[string]*string{
+		FieldTitle:       &result.Title,
+		FieldDescription: &result.Description,
+		FieldImage:       &result.Image,
+		FieldPublished:   &result.Published,
 	}
-
-	fallback, err := ExtractHTMLFallback(bytes.NewReader(htmlContent), targetURL)
-	if err != nil {
-		log.Warnf("failed to extract HTML fallback for %s: %v", targetURL, err)
-		return
+	for field, dst := range fields {
+		value, source := pickSource(order[field], fallback.candidates[field])
+		*dst = value
+		result.setSource(field, source)
 	}
 
-	if result.Title == "" {
-		result.Title = fallback.Title
+	result.Article = fallback.Article
+	if result.Article != nil && result.Published != "" {
+		result.Article.Published = result.Published
+		result.Article.Sources[ArticleFieldPublished] = result.Sources[FieldPublished]
 	}
-	if result.Description == "" {
-		result.Description = fallback.Description
+	if result.Article != nil && len(result.Article.Sources) == 0 {
+		result.Article = nil
 	}
-	if result.Image == "" {
-		result.Image = fallback.Image
+
+	result.Product = fallback.Product
+	if result.Product != nil && result.Product.Name == "" && result.Title != "" {
+		result.Product.Name = result.Title
+		result.Product.Sources[ProductFieldName] = result.Sources[FieldTitle]
 	}
 }
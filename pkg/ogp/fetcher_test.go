@@ -22,12 +22,13 @@ func TestFetch_GeneralURL_ReturnsOGPData(t *testing.T) {
 				<meta property="og:title" content="Test Page">
 				<meta property="og:description" content="Test Description">
 				<meta property="og:image" content="https://example.com/img.png">
+				<meta property="og:site_name" content="Example">
 			</head><body></body></html>`
 			return []byte(html), 200, nil
 		},
 	}
 	fetcher := NewFetcher(client)
-	result := fetcher.Fetch("https://example.com")
+	result := fetcher.Fetch(t.Context(), "https://example.com")
 
 	if result.Err != nil {
 		t.Fatalf("unexpected error: %v", result.Err)
@@ -41,6 +42,9 @@ func TestFetch_GeneralURL_ReturnsOGPData(t *testing.T) {
 	if result.Image != "https://example.com/img.png" {
 		t.Errorf("got image %q, want %q", result.Image, "https://example.com/img.png")
 	}
+	if result.SiteName != "Example" {
+		t.Errorf("got site name %q, want %q", result.SiteName, "Example")
+	}
 }
 
 func TestFetch_GeneralURL_HTMLFallback(t *testing.T) {
@@ -54,7 +58,7 @@ func TestFetch_GeneralURL_HTMLFallback(t *testing.T) {
 		},
 	}
 	fetcher := NewFetcher(client)
-	result := fetcher.Fetch("https://example.com")
+	result := fetcher.Fetch(t.Context(), "https://example.com")
 
 	if result.Err != nil {
 		t.Fatalf("unexpected error: %v", result.Err)
@@ -81,7 +85,7 @@ func TestFetch_TwitterURL_OEmbed(t *testing.T) {
 		},
 	}
 	fetcher := NewFetcher(client)
-	result := fetcher.Fetch("https://x.com/TestUser/status/123")
+	result := fetcher.Fetch(t.Context(), "https://x.com/TestUser/status/123")
 
 	if result.Err != nil {
 		t.Fatalf("unexpected error: %v", result.Err)
@@ -107,7 +111,7 @@ func TestFetch_TwitterURL_OEmbedFallbackToGeneralOGP(t *testing.T) {
 		},
 	}
 	fetcher := NewFetcher(client)
-	result := fetcher.Fetch("https://x.com/user/status/456")
+	result := fetcher.Fetch(t.Context(), "https://x.com/user/status/456")
 
 	if result.Err != nil {
 		t.Fatalf("unexpected error: %v", result.Err)
@@ -140,7 +144,7 @@ func TestFetch_TwitterURL_TcoExpansion(t *testing.T) {
 		},
 	}
 	fetcher := NewFetcher(client)
-	result := fetcher.Fetch("https://x.com/LinkUser/status/789")
+	result := fetcher.Fetch(t.Context(), "https://x.com/LinkUser/status/789")
 
 	if result.Err != nil {
 		t.Fatalf("unexpected error: %v", result.Err)
@@ -154,6 +158,17 @@ func TestFetch_TwitterURL_TcoExpansion(t *testing.T) {
 	if result.Image != "https://article.com/img.png" {
 		t.Errorf("got image %q, want %q", result.Image, "https://article.com/img.png")
 	}
+	wantSources := map[string]Source{
+		FieldTitle:       SourceOEmbed,
+		FieldDescription: SourceLinkedContent,
+		FieldImage:       SourceLinkedContent,
+		FieldSiteName:    SourceOEmbed,
+	}
+	for field, want := range wantSources {
+		if got := result.Sources[field]; got != want {
+			t.Errorf("got %s source %q, want %q", field, got, want)
+		}
+	}
 }
 
 func TestFetch_HTTPError(t *testing.T) {
@@ -163,7 +178,7 @@ func TestFetch_HTTPError(t *testing.T) {
 		},
 	}
 	fetcher := NewFetcher(client)
-	result := fetcher.Fetch("https://unreachable.example.com")
+	result := fetcher.Fetch(t.Context(), "https://unreachable.example.com")
 
 	if result.Err == nil {
 		t.Error("expected error, got nil")
@@ -0,0 +1,382 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const githubSiteName = "GitHub"
+
+// GitHub object kinds.
+const (
+	GitHubRepository  = "repository"
+	GitHubIssue       = "issue"
+	GitHubPullRequest = "pull_request"
+	GitHubRelease     = "release"
+	GitHubCommit      = "commit"
+	GitHubGist        = "gist"
+)
+
+// githubReservedOwners are first path segments of github.com that are not users.
+var githubReservedOwners = []string{
+	"about", "apps", "collections", "contact", "customer-stories", "enterprise",
+	"explore", "features", "login", "marketplace", "new", "notifications",
+	"orgs", "organizations", "pricing", "pulls", "issues", "search", "security",
+	"settings", "signup", "sponsors", "team", "topics", "trending", "users",
+}
+
+// GitHub holds metadata of a GitHub repository, issue, pull request,
+// release, commit or gist.
+type GitHub struct {
+	Kind     string   `json:"kind"`
+	Repo     string   `json:"repo,omitempty"`
+	Number   int      `json:"number,omitempty"`
+	Tag      string   `json:"tag,omitempty"`
+	SHA      string   `json:"sha,omitempty"`
+	Stars    int      `json:"stars,omitempty"`
+	Forks    int      `json:"forks,omitempty"`
+	Language string   `json:"language,omitempty"`
+	License  string   `json:"license,omitempty"`
+	Topics   []string `json:"topics,omitempty"`
+	// State is open, closed or merged for issues and pull requests.
+	State  string `json:"state,omitempty"`
+	Draft  bool   `json:"draft,omitempty"`
+	Author string `json:"author,omitempty"`
+}
+
+// githubInstance is the API of a GitHub Enterprise Server host.
+type githubInstance struct {
+	api   string
+	token string
+}
+
+// githubRef is a GitHub URL broken down into the object it points to.
+type githubRef struct {
+	// host is the GitHub Enterprise Server host, empty for github.com.
+	host   string
+	kind   string
+	owner  string
+	repo   string
+	number int
+	tag    string
+	sha    string
+	gist   string
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+type githubRepository struct {
+	FullName    string     `json:"full_name"`
+	Description string     `json:"description"`
+	Stars       int        `json:"stargazers_count"`
+	Forks       int        `json:"forks_count"`
+	Language    string     `json:"language"`
+	Topics      []string   `json:"topics"`
+	Owner       githubUser `json:"owner"`
+	License     *struct {
+		SPDXID string `json:"spdx_id"`
+		Name   string `json:"name"`
+	} `json:"license"`
+}
+
+type githubIssue struct {
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	Draft     bool       `json:"draft"`
+	MergedAt  string     `json:"merged_at"`
+	User      githubUser `json:"user"`
+	CreatedAt string     `json:"created_at"`
+}
+
+type githubReleaseResponse struct {
+	Name        string     `json:"name"`
+	TagName     string     `json:"tag_name"`
+	Body        string     `json:"body"`
+	Author      githubUser `json:"author"`
+	PublishedAt string     `json:"published_at"`
+}
+
+type githubCommitResponse struct {
+	SHA    string     `json:"sha"`
+	Author githubUser `json:"author"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string `json:"name"`
+			Date string `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+type githubGistResponse struct {
+	Description string              `json:"description"`
+	Owner       githubUser          `json:"owner"`
+	CreatedAt   string              `json:"created_at"`
+	Files       map[string]struct{} `json:"files"`
+}
+
+// WithGitHubToken sets the token used to authenticate github.com API
+// requests, raising the rate limit and giving access to private repositories.
+func WithGitHubToken(token string) FetcherOption {
+	return func(f *Fetcher) { f.githubToken = token }
+}
+
+// WithGitHubHosts adds GitHub Enterprise Server hosts handled by the GitHub
+// provider, using their API at https://<host>/api/v3 without a token.
+func WithGitHubHosts(hosts ...string) FetcherOption {
+	return func(f *Fetcher) {
+		for _, host := range hosts {
+			WithGitHubHost(host, "", "")(f)
+		}
+	}
+}
+
+// WithGitHubHost adds a GitHub Enterprise Server host with its API base URL
+// and token. An empty apiURL defaults to https://<host>/api/v3.
+func WithGitHubHost(host, apiURL, token string) FetcherOption {
+	return func(f *Fetcher) {
+		host = strings.ToLower(host)
+		if apiURL == "" {
+			apiURL = "https://" + host + "/api/v3"
+		}
+		if f.githubHosts == nil {
+			f.githubHosts = make(map[string]githubInstance)
+		}
+		f.githubHosts[host] = githubInstance{api: strings.TrimSuffix(apiURL, "/"), token: token}
+	}
+}
+
+// isGitHubURL checks if the URL points to a GitHub object the provider handles.
+func (f *Fetcher) isGitHubURL(targetURL string) bool {
+	_, ok := f.parseGitHubURL(targetURL)
+	return ok
+}
+
+func (f *Fetcher) parseGitHubURL(targetURL string) (githubRef, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return githubRef{}, false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+
+	if host == "gist.github.com" {
+		// gist.github.com/<user>/<id> or gist.github.com/<id>
+		id := segments[len(segments)-1]
+		if id == "" || len(segments) > 2 {
+			return githubRef{}, false
+		}
+		return githubRef{kind: GitHubGist, gist: id}, true
+	}
+	ref := githubRef{}
+	if host != "github.com" && host != "www.github.com" {
+		if _, ok := f.githubHosts[host]; !ok {
+			return githubRef{}, false
+		}
+		ref.host = host
+	}
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" || slices.Contains(githubReservedOwners, segments[0]) {
+		return githubRef{}, false
+	}
+
+	ref.owner, ref.repo = segments[0], strings.TrimSuffix(segments[1], ".git")
+	rest := segments[2:]
+	switch {
+	case len(rest) == 0:
+		ref.kind = GitHubRepository
+	case len(rest) >= 2 && (rest[0] == "issues" || rest[0] == "pull"):
+		n, err := strconv.Atoi(rest[1])
+		if err != nil {
+			return githubRef{}, false
+		}
+		ref.kind, ref.number = GitHubIssue, n
+		if rest[0] == "pull" {
+			ref.kind = GitHubPullRequest
+		}
+	case len(rest) >= 3 && rest[0] == "releases" && rest[1] == "tag":
+		ref.kind, ref.tag = GitHubRelease, strings.Join(rest[2:], "/")
+	case len(rest) == 2 && rest[0] == "commit":
+		ref.kind, ref.sha = GitHubCommit, rest[1]
+	default:
+		return githubRef{}, false
+	}
+	return ref, true
+}
+
+func (f *Fetcher) fetchGitHub(ctx context.Context, targetURL string) *Result {
+	ref, _ := f.parseGitHubURL(targetURL)
+	result := &Result{URL: targetURL, SiteName: githubSiteName}
+
+	var err error
+	switch ref.kind {
+	case GitHubRepository:
+		err = f.fetchGitHubRepository(ctx, result, ref)
+	case GitHubIssue, GitHubPullRequest:
+		err = f.fetchGitHubIssue(ctx, result, ref)
+	case GitHubRelease:
+		err = f.fetchGitHubRelease(ctx, result, ref)
+	case GitHubCommit:
+		err = f.fetchGitHubCommit(ctx, result, ref)
+	case GitHubGist:
+		err = f.fetchGitHubGist(ctx, result, ref)
+	}
+	if err != nil {
+		log.Warnf("GitHub API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	if ref.kind != GitHubGist {
+		result.Image = githubOGImageURL(targetURL)
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
+
+// githubAPI fetches path from the API of the host ref points to, with the
+// token configured for that host.
+func (f *Fetcher) githubAPI(ctx context.Context, ref githubRef, path string, v any) error {
+	instance := githubInstance{api: f.endpoint(EndpointGitHubAPI), token: f.githubToken}
+	if ref.host != "" {
+		instance = f.githubHosts[ref.host]
+	}
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if instance.token != "" {
+		header.Set("Authorization", "Bearer "+instance.token)
+	}
+	return f.fetchJSON(ctx, instance.api+path, v, header)
+}
+
+func (ref githubRef) repoPath() string {
+	return "/repos/" + url.PathEscape(ref.owner) + "/" + url.PathEscape(ref.repo)
+}
+
+func (f *Fetcher) fetchGitHubRepository(ctx context.Context, result *Result, ref githubRef) error {
+	var repo githubRepository
+	if err := f.githubAPI(ctx, ref, ref.repoPath(), &repo); err != nil {
+		return err
+	}
+	result.Title = repo.FullName
+	result.Description = repo.Description
+	result.GitHub = &GitHub{
+		Kind:     GitHubRepository,
+		Repo:     repo.FullName,
+		Stars:    repo.Stars,
+		Forks:    repo.Forks,
+		Language: repo.Language,
+		Topics:   repo.Topics,
+		Author:   repo.Owner.Login,
+	}
+	if repo.License != nil {
+		result.GitHub.License = repo.License.SPDXID
+		if result.GitHub.License == "" || result.GitHub.License == "NOASSERTION" {
+			result.GitHub.License = repo.License.Name
+		}
+	}
+	return nil
+}
+
+func (f *Fetcher) fetchGitHubIssue(ctx context.Context, result *Result, ref githubRef) error {
+	kind, label := "issues", "Issue"
+	if ref.kind == GitHubPullRequest {
+		kind, label = "pulls", "Pull Request"
+	}
+	var issue githubIssue
+	if err := f.githubAPI(ctx, ref, fmt.Sprintf("%s/%s/%d", ref.repoPath(), kind, ref.number), &issue); err != nil {
+		return err
+	}
+	repo := ref.owner + "/" + ref.repo
+	result.Title = fmt.Sprintf("%s · %s #%d · %s", issue.Title, label, ref.number, repo)
+	result.Description = excerpt(issue.Body, maxExcerptLength)
+	result.Published = normalizeTime(issue.CreatedAt)
+	state := issue.State
+	if issue.MergedAt != "" {
+		state = "merged"
+	}
+	result.GitHub = &GitHub{
+		Kind:   ref.kind,
+		Repo:   repo,
+		Number: ref.number,
+		State:  state,
+		Draft:  issue.Draft,
+		Author: issue.User.Login,
+	}
+	return nil
+}
+
+func (f *Fetcher) fetchGitHubRelease(ctx context.Context, result *Result, ref githubRef) error {
+	var release githubReleaseResponse
+	if err := f.githubAPI(ctx, ref, ref.repoPath()+"/releases/tags/"+url.PathEscape(ref.tag), &release); err != nil {
+		return err
+	}
+	repo := ref.owner + "/" + ref.repo
+	name := release.Name
+	if name == "" {
+		name = release.TagName
+	}
+	result.Title = fmt.Sprintf("Release %s · %s", name, repo)
+	result.Description = excerpt(release.Body, maxExcerptLength)
+	result.Published = normalizeTime(release.PublishedAt)
+	result.GitHub = &GitHub{Kind: GitHubRelease, Repo: repo, Tag: release.TagName, Author: release.Author.Login}
+	return nil
+}
+
+func (f *Fetcher) fetchGitHubCommit(ctx context.Context, result *Result, ref githubRef) error {
+	var commit githubCommitResponse
+	if err := f.githubAPI(ctx, ref, ref.repoPath()+"/commits/"+url.PathEscape(ref.sha), &commit); err != nil {
+		return err
+	}
+	repo := ref.owner + "/" + ref.repo
+	subject, body, _ := strings.Cut(commit.Commit.Message, "\n")
+	result.Title = fmt.Sprintf("%s · %s@%.7s", subject, repo, commit.SHA)
+	result.Description = excerpt(body, maxExcerptLength)
+	result.Published = normalizeTime(commit.Commit.Author.Date)
+	author := commit.Author.Login
+	if author == "" {
+		author = commit.Commit.Author.Name
+	}
+	result.GitHub = &GitHub{Kind: GitHubCommit, Repo: repo, SHA: commit.SHA, Author: author}
+	return nil
+}
+
+func (f *Fetcher) fetchGitHubGist(ctx context.Context, result *Result, ref githubRef) error {
+	var gist githubGistResponse
+	if err := f.githubAPI(ctx, ref, "/gists/"+url.PathEscape(ref.gist), &gist); err != nil {
+		return err
+	}
+	files := slices.Sorted(maps.Keys(gist.Files))
+	result.Title = gist.Description
+	if len(files) > 0 {
+		result.Description = strings.Join(files, ", ")
+		if result.Title == "" {
+			result.Title = files[0]
+		}
+	}
+	result.Published = normalizeTime(gist.CreatedAt)
+	result.GitHub = &GitHub{Kind: GitHubGist, Author: gist.Owner.Login}
+	return nil
+}
+
+// githubOGImageURL returns the social card GitHub renders for an object.
+// The first path segment is a cache key and may be any value.
+func githubOGImageURL(targetURL string) string {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return ""
+	}
+	if host := strings.ToLower(parsed.Hostname()); host != "github.com" && host != "www.github.com" {
+		return ""
+	}
+	return "https://opengraph.githubassets.com/1/" + strings.Trim(parsed.Path, "/")
+}
@@ -0,0 +1,226 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGitHubURL(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		want   githubRef
+		wantOK bool
+	}{
+		"repository": {
+			url:    "https://github.com/spf13/cobra-cli",
+			want:   githubRef{kind: GitHubRepository, owner: "spf13", repo: "cobra-cli"},
+			wantOK: true,
+		},
+		"repository with .git": {
+			url:    "https://github.com/spf13/cobra-cli.git",
+			want:   githubRef{kind: GitHubRepository, owner: "spf13", repo: "cobra-cli"},
+			wantOK: true,
+		},
+		"issue": {
+			url:    "https://github.com/golang/go/issues/123#issuecomment-1",
+			want:   githubRef{kind: GitHubIssue, owner: "golang", repo: "go", number: 123},
+			wantOK: true,
+		},
+		"pull request files": {
+			url:    "https://github.com/golang/go/pull/45/files",
+			want:   githubRef{kind: GitHubPullRequest, owner: "golang", repo: "go", number: 45},
+			wantOK: true,
+		},
+		"release": {
+			url:    "https://github.com/spf13/cobra/releases/tag/v1.8.0",
+			want:   githubRef{kind: GitHubRelease, owner: "spf13", repo: "cobra", tag: "v1.8.0"},
+			wantOK: true,
+		},
+		"commit": {
+			url:    "https://github.com/spf13/cobra/commit/abc1234",
+			want:   githubRef{kind: GitHubCommit, owner: "spf13", repo: "cobra", sha: "abc1234"},
+			wantOK: true,
+		},
+		"gist": {
+			url:    "https://gist.github.com/octocat/aa5a315d61ae9438b18d",
+			want:   githubRef{kind: GitHubGist, gist: "aa5a315d61ae9438b18d"},
+			wantOK: true,
+		},
+		"enterprise host": {
+			url:    "https://ghe.example.com/platform/app",
+			want:   githubRef{host: "ghe.example.com", kind: GitHubRepository, owner: "platform", repo: "app"},
+			wantOK: true,
+		},
+		"user profile":   {url: "https://github.com/spf13"},
+		"reserved owner": {url: "https://github.com/topics/go"},
+		"tree":           {url: "https://github.com/spf13/cobra/tree/main/doc"},
+		"issue list":     {url: "https://github.com/golang/go/issues"},
+		"other host":     {url: "https://gitlab.com/spf13/cobra"},
+	}
+
+	f := NewFetcher(nil, WithGitHubHosts("GHE.example.com"))
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := f.parseGitHubURL(tc.url)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("parseGitHubURL(%q) = %+v, %v, want %+v, %v", tc.url, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetch_GitHub(t *testing.T) {
+	responses := map[string]string{
+		"/repos/spf13/cobra-cli": `{"full_name": "spf13/cobra-cli", "description": "Cobra CLI tool",
+			"stargazers_count": 1200, "forks_count": 100, "language": "Go", "topics": ["cli", "go"],
+			"owner": {"login": "spf13"}, "license": {"spdx_id": "Apache-2.0", "name": "Apache License 2.0"}}`,
+		"/repos/golang/go/pulls/45": `{"title": "Fix a bug", "body": "This fixes\nthe bug.", "state": "closed",
+			"merged_at": "2024-03-02T00:00:00Z", "user": {"login": "gopher"}, "created_at": "2024-03-01T00:00:00Z"}`,
+		"/repos/spf13/cobra/releases/tags/v1.8.0": `{"name": "", "tag_name": "v1.8.0", "body": "Notes",
+			"author": {"login": "maintainer"}, "published_at": "2024-03-01T00:00:00Z"}`,
+		"/repos/spf13/cobra/commits/abc1234": `{"sha": "abc1234def5678", "author": null,
+			"commit": {"message": "Add feature\n\nLonger explanation.", "author": {"name": "Jane", "date": "2024-03-01T00:00:00Z"}}}`,
+		"/gists/aa5a": `{"description": "", "owner": {"login": "octocat"}, "created_at": "2024-03-01T00:00:00Z",
+			"files": {"b.go": {}, "a.md": {}}}`,
+	}
+	var authHeaders []string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			authHeaders = append(authHeaders, req.Header.Get("Authorization"))
+			path := strings.TrimPrefix(req.URL.String(), "http://stub")
+			if body, ok := responses[path]; ok {
+				return []byte(body), 200, nil
+			}
+			return nil, 404, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithEndpoint(EndpointGitHubAPI, "http://stub"), WithGitHubToken("secret"))
+
+	tests := map[string]struct {
+		url       string
+		wantTitle string
+		wantDesc  string
+		want      GitHub
+	}{
+		"repository": {
+			url:       "https://github.com/spf13/cobra-cli",
+			wantTitle: "spf13/cobra-cli",
+			wantDesc:  "Cobra CLI tool",
+			want: GitHub{Kind: GitHubRepository, Repo: "spf13/cobra-cli", Stars: 1200, Forks: 100,
+				Language: "Go", License: "Apache-2.0", Topics: []string{"cli", "go"}, Author: "spf13"},
+		},
+		"merged pull request": {
+			url:       "https://github.com/golang/go/pull/45",
+			wantTitle: "Fix a bug · Pull Request #45 · golang/go",
+			wantDesc:  "This fixes the bug.",
+			want:      GitHub{Kind: GitHubPullRequest, Repo: "golang/go", Number: 45, State: "merged", Author: "gopher"},
+		},
+		"release without name": {
+			url:       "https://github.com/spf13/cobra/releases/tag/v1.8.0",
+			wantTitle: "Release v1.8.0 · spf13/cobra",
+			wantDesc:  "Notes",
+			want:      GitHub{Kind: GitHubRelease, Repo: "spf13/cobra", Tag: "v1.8.0", Author: "maintainer"},
+		},
+		"commit": {
+			url:       "https://github.com/spf13/cobra/commit/abc1234",
+			wantTitle: "Add feature · spf13/cobra@abc1234",
+			wantDesc:  "Longer explanation.",
+			want:      GitHub{Kind: GitHubCommit, Repo: "spf13/cobra", SHA: "abc1234def5678", Author: "Jane"},
+		},
+		"gist": {
+			url:       "https://gist.github.com/octocat/aa5a",
+			wantTitle: "a.md",
+			wantDesc:  "a.md, b.go",
+			want:      GitHub{Kind: GitHubGist, Author: "octocat"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := fetcher.Fetch(t.Context(), tc.url)
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			if result.Title != tc.wantTitle {
+				t.Errorf("got title %q, want %q", result.Title, tc.wantTitle)
+			}
+			if result.Description != tc.wantDesc {
+				t.Errorf("got description %q, want %q", result.Description, tc.wantDesc)
+			}
+			if result.SiteName != "GitHub" || result.Sources[FieldTitle] != SourceAPI {
+				t.Errorf("got site name %q and title source %q", result.SiteName, result.Sources[FieldTitle])
+			}
+			got := result.GitHub
+			if got == nil {
+				t.Fatal("got nil GitHub metadata")
+			}
+			if !reflect.DeepEqual(*got, tc.want) {
+				t.Errorf("got %+v, want %+v", *got, tc.want)
+			}
+		})
+	}
+
+	for _, h := range authHeaders {
+		if h != "Bearer secret" {
+			t.Errorf("got Authorization %q, want the token", h)
+		}
+	}
+}
+
+func TestFetch_GitHub_EnterpriseHosts(t *testing.T) {
+	var requests []string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			requests = append(requests, req.URL.String()+" "+req.Header.Get("Authorization"))
+			return []byte(`{"full_name": "platform/app"}`), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client,
+		WithGitHubToken("public"),
+		WithGitHubHosts("ghe.example.com"),
+		WithGitHubHost("code.example.org", "https://api.code.example.org/", "private"),
+	)
+
+	tests := map[string]struct {
+		url  string
+		want string
+	}{
+		"github.com":     {url: "https://github.com/platform/app", want: "https://api.github.com/repos/platform/app Bearer public"},
+		"default api":    {url: "https://ghe.example.com/platform/app", want: "https://ghe.example.com/api/v3/repos/platform/app "},
+		"configured api": {url: "https://code.example.org/platform/app", want: "https://api.code.example.org/repos/platform/app Bearer private"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests = nil
+			result := fetcher.Fetch(t.Context(), tc.url)
+			if result.GitHub == nil {
+				t.Fatalf("got no GitHub metadata for %s", tc.url)
+			}
+			if len(requests) != 1 || requests[0] != tc.want {
+				t.Errorf("got requests %q, want %q", requests, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_GitHubFallback(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if strings.HasPrefix(req.URL.String(), "https://api.github.com/") {
+				return []byte(`{"message": "API rate limit exceeded"}`), 403, nil
+			}
+			return []byte(`<html><head><meta property="og:title" content="HTML Title"></head></html>`), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://github.com/spf13/cobra-cli")
+
+	if result.Title != "HTML Title" {
+		t.Errorf("got title %q, want %q", result.Title, "HTML Title")
+	}
+	if result.GitHub != nil {
+		t.Errorf("got GitHub metadata %+v, want nil", result.GitHub)
+	}
+}
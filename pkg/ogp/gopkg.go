@@ -0,0 +1,123 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/andybalholm/cascadia"
+	log "github.com/sirupsen/logrus"
+)
+
+const goPackageSiteName = "Go Packages"
+
+var (
+	goLicenseSelector     = cascadia.MustCompile(`[data-test-id="UnitHeader-licenses"] a`)
+	goRepositorySelector  = cascadia.MustCompile(`.UnitMeta-repo a`)
+	goDescriptionSelector = cascadia.MustCompile(`meta[name="description"]`)
+)
+
+type goProxyInfo struct {
+	Version string `json:"Version"`
+	Time    string `json:"Time"`
+}
+
+// IsGoPackageURL checks if the URL is a pkg.go.dev page of a module or
+// package outside the standard library.
+func IsGoPackageURL(targetURL string) bool {
+	_, ok := goPackagePath(targetURL)
+	return ok
+}
+
+// goPackagePath returns the import path of a pkg.go.dev URL, without the
+// version suffix.
+func goPackagePath(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Hostname() != "pkg.go.dev" {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i, segment := range segments {
+		segments[i], _, _ = strings.Cut(segment, "@")
+	}
+	// standard library paths and site pages have no dot in their first element
+	if len(segments) < 2 || !strings.Contains(segments[0], ".") {
+		return "", false
+	}
+	return strings.Join(segments, "/"), true
+}
+
+func (f *Fetcher) fetchGoPackage(ctx context.Context, targetURL string) *Result {
+	path, _ := goPackagePath(targetURL)
+
+	module, info, err := f.goModule(ctx, path)
+	if err != nil {
+		log.Warnf("Go module proxy failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	pkg := &Package{
+		Registry:  RegistryGo,
+		Name:      path,
+		Version:   info.Version,
+		Published: normalizeTime(info.Time),
+	}
+	// the proxy serves no description, license or image, so they are read from the page
+	var image string
+	if doc, err := f.fetchDocument(ctx, targetURL); err != nil {
+		log.Debugf("failed to fetch %s: %v", targetURL, err)
+	} else {
+		image = documentImage(doc, targetURL)
+		if n := cascadia.Query(doc, goDescriptionSelector); n != nil {
+			pkg.Description = getAttr(n, "content")
+		}
+		if n := cascadia.Query(doc, goLicenseSelector); n != nil {
+			pkg.License = collapseSpace(nodeText(n))
+		}
+		if n := cascadia.Query(doc, goRepositorySelector); n != nil {
+			pkg.Repository = getAttr(n, "href")
+		}
+	}
+	if pkg.Repository == "" {
+		pkg.Repository = goRepositoryURL(module)
+	}
+	return packageResult(targetURL, goPackageSiteName, pkg, image)
+}
+
+// goModule finds the module providing the package at path by asking the
+// proxy for the latest version of each path prefix, longest first.
+func (f *Fetcher) goModule(ctx context.Context, path string) (string, *goProxyInfo, error) {
+	var err error
+	for module := path; strings.Contains(module, "/"); module = module[:strings.LastIndex(module, "/")] {
+		var info goProxyInfo
+		if err = f.fetchJSON(ctx, f.endpoint(EndpointGoProxy)+"/"+escapeModulePath(module)+"/@latest", &info, nil); err == nil {
+			return module, &info, nil
+		}
+	}
+	return "", nil, err
+}
+
+// escapeModulePath escapes a module path for the proxy protocol, which
+// encodes upper-case letters as '!' followed by the lower-case letter.
+func escapeModulePath(path string) string {
+	var sb strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			sb.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// goRepositoryURL returns the repository of a module hosted on a well-known code host.
+func goRepositoryURL(module string) string {
+	segments := strings.Split(module, "/")
+	for _, host := range codeHosts {
+		if segments[0] == host && len(segments) >= 3 {
+			return "https://" + strings.Join(segments[:3], "/")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const hackerNewsSiteName = "Hacker News"
+
+type hackerNewsItem struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	By          string `json:"by"`
+	Time        int64  `json:"time"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Text        string `json:"text"`
+	Score       int    `json:"score"`
+	Descendants int    `json:"descendants"`
+}
+
+// IsHackerNewsURL checks if the URL is a Hacker News item: news.ycombinator.com/item?id=<id>.
+func IsHackerNewsURL(targetURL string) bool {
+	_, ok := hackerNewsItemID(targetURL)
+	return ok
+}
+
+func hackerNewsItemID(targetURL string) (int, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Hostname() != "news.ycombinator.com" || parsed.Path != "/item" {
+		return 0, false
+	}
+	id, err := strconv.Atoi(parsed.Query().Get("id"))
+	return id, err == nil && id > 0
+}
+
+func (f *Fetcher) fetchHackerNews(ctx context.Context, targetURL string) *Result {
+	id, _ := hackerNewsItemID(targetURL)
+
+	var item hackerNewsItem
+	err := f.fetchJSON(ctx, fmt.Sprintf("%s/item/%d.json", f.endpoint(EndpointHackerNewsAPI), id), &item, nil)
+	if err == nil && item.ID == 0 {
+		// the API answers null for unknown items
+		err = fmt.Errorf("item %d not found", id)
+	}
+	if err != nil {
+		log.Warnf("Hacker News API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+
+	created := time.Unix(item.Time, 0).UTC().Format(time.RFC3339)
+	text := htmlToText(item.Text)
+	result := &Result{
+		URL:         targetURL,
+		Title:       item.Title,
+		Description: excerpt(text, maxExcerptLength),
+		SiteName:    hackerNewsSiteName,
+		Published:   created,
+		Post: &Post{
+			Author:    item.By,
+			AuthorURL: "https://news.ycombinator.com/user?id=" + url.QueryEscape(item.By),
+			Text:      text,
+			Created:   created,
+			Replies:   item.Descendants,
+			Score:     item.Score,
+		},
+	}
+	if result.Title == "" {
+		// comments have no title
+		result.Title = fmt.Sprintf("Comment by %s", item.By)
+	}
+	result.recordSources(SourceAPI)
+	f.applyLinked(ctx, result, item.URL)
+	return result
+}
@@ -0,0 +1,115 @@
+package ogp
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHackerNewsItemID(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		want   int
+		wantOK bool
+	}{
+		"item":       {url: "https://news.ycombinator.com/item?id=39000000", want: 39000000, wantOK: true},
+		"front page": {url: "https://news.ycombinator.com/news"},
+		"bad id":     {url: "https://news.ycombinator.com/item?id=abc"},
+		"user":       {url: "https://news.ycombinator.com/user?id=pg"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := hackerNewsItemID(tc.url)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("hackerNewsItemID(%q) = %d, %v, want %d, %v", tc.url, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetch_HackerNews(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch req.URL.String() {
+			case "http://stub/item/1.json":
+				return []byte(`{"id": 1, "type": "story", "by": "pg", "time": 1160418111, "title": "Y Combinator",
+					"url": "https://example.com/yc", "score": 57, "descendants": 15}`), 200, nil
+			case "http://stub/item/2.json":
+				return []byte(`{"id": 2, "type": "comment", "by": "gopher", "time": 1160418111,
+					"text": "Nice <i>post</i>.<p>Second paragraph"}`), 200, nil
+			case "http://stub/item/3.json":
+				return []byte(`null`), 200, nil
+			case "https://example.com/yc":
+				return []byte(`<html><head><meta property="og:title" content="YC">
+					<meta property="og:description" content="Startup funding">
+					<meta property="og:image" content="https://example.com/yc.png"></head></html>`), 200, nil
+			}
+			return []byte(`<html><head><title>Hacker News</title></head></html>`), 200, nil
+		},
+	}
+
+	tests := map[string]struct {
+		url        string
+		previews   bool
+		wantTitle  string
+		wantDesc   string
+		wantImage  string
+		wantLinked *Result
+		wantPost   bool
+	}{
+		"story": {
+			url:        "https://news.ycombinator.com/item?id=1",
+			wantTitle:  "Y Combinator",
+			wantLinked: &Result{URL: "https://example.com/yc"},
+			wantPost:   true,
+		},
+		"story with linked preview": {
+			url:        "https://news.ycombinator.com/item?id=1",
+			previews:   true,
+			wantTitle:  "Y Combinator",
+			wantDesc:   "Startup funding",
+			wantImage:  "https://example.com/yc.png",
+			wantLinked: &Result{URL: "https://example.com/yc", Title: "YC", Image: "https://example.com/yc.png"},
+			wantPost:   true,
+		},
+		"comment": {
+			url:       "https://news.ycombinator.com/item?id=2",
+			wantTitle: "Comment by gopher",
+			wantDesc:  "Nice post. Second paragraph",
+			wantPost:  true,
+		},
+		"unknown item": {
+			url:       "https://news.ycombinator.com/item?id=3",
+			wantTitle: "Hacker News",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			fetcher := NewFetcher(client, WithEndpoint(EndpointHackerNewsAPI, "http://stub"), WithLinkedPreviews(tc.previews))
+			result := fetcher.Fetch(t.Context(), tc.url)
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			if result.Title != tc.wantTitle || result.Description != tc.wantDesc || result.Image != tc.wantImage {
+				t.Errorf("got title %q, description %q and image %q", result.Title, result.Description, result.Image)
+			}
+			if (result.Post != nil) != tc.wantPost {
+				t.Fatalf("got post %+v, want post: %v", result.Post, tc.wantPost)
+			}
+			if !tc.wantPost {
+				return
+			}
+			linked := result.Post.Linked
+			if (linked == nil) != (tc.wantLinked == nil) {
+				t.Fatalf("got linked %+v, want %+v", linked, tc.wantLinked)
+			}
+			if linked != nil && (linked.URL != tc.wantLinked.URL || linked.Title != tc.wantLinked.Title || linked.Image != tc.wantLinked.Image) {
+				t.Errorf("got linked %+v, want %+v", linked, tc.wantLinked)
+			}
+			if tc.previews && result.Sources[FieldImage] != SourceLinkedContent {
+				t.Errorf("got image source %q, want %q", result.Sources[FieldImage], SourceLinkedContent)
+			}
+		})
+	}
+}
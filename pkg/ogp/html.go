@@ -4,16 +4,56 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/net/html"
 )
 
 // HTMLFallbackData contains fallback metadata extracted from HTML.
 type HTMLFallbackData struct {
+	// Title, Description, Image and Published are the values chosen in the
+	// default source order. They are only set by ExtractHTMLFallback.
 	Title       string
 	Description string
 	Image       string
+	Published   string
+	// ImageCandidates lists every image found in the document, in order.
+	ImageCandidates []ImageCandidate
+	// Icons lists the <link rel=icon> variants of the document, in order.
+	Icons       []Icon
+	ManifestURL string
+	// Article is the article metadata of the document, nil if none.
+	Article *Article
+	// Product is the product metadata of the document, nil if none.
+	Product *Product
+	// JSONLD lists the objects of every JSON-LD block, flattened.
+	JSONLD []map[string]any
+	// Sources records where Title, Description, Image and Published came
+	// from, keyed by field name.
+	Sources map[string]Source
+
+	article articleCollector
+	product productCollector
+	// candidates holds the first value of each field per source.
+	candidates map[string]map[Source]string
+}
+
+// addCandidate records value for a field unless the source already has one.
+func (fallback *HTMLFallbackData) addCandidate(field string, source Source, value string) {
+	if value == "" {
+		return
+	}
+	if fallback.candidates == nil {
+		fallback.candidates = make(map[string]map[Source]string)
+	}
+	if fallback.candidates[field] == nil {
+		fallback.candidates[field] = make(map[Source]string)
+	}
+	if _, ok := fallback.candidates[field][source]; !ok {
+		fallback.candidates[field][source] = value
+	}
 }
 
 // ExtractHTMLFallback extracts basic metadata from HTML as fallback.
@@ -23,9 +63,45 @@ func ExtractHTMLFallback(reader io.Reader, baseURL string) (*HTMLFallbackData, e
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
+	fallback := extractHTMLFallback(doc, baseURL)
+	fallback.pickDefaults()
+	return fallback, nil
+}
+
+// pickDefaults fills the policy fields from the candidates in the default
+// source order, as applyPolicy does for fetched pages.
+func (fallback *HTMLFallbackData) pickDefaults() {
+	fields := map[string]*string{
+		FieldTitle:       &fallback.Title,
+		FieldDescription: &fallback.Description,
+		FieldImage:       &fallback.Image,
+		FieldPublished:   &fallback.Published,
+	}
+	fallback.Sources = make(map[string]Source)
+	for field, dst := range fields {
+		value, source := pickSource(defaultSourceOrder[field], fallback.candidates[field])
+		*dst = value
+		if source != "" {
+			fallback.Sources[field] = source
+		}
+	}
+}
+
+func extractHTMLFallback(doc *html.Node, baseURL string) *HTMLFallbackData {
 	fallback := &HTMLFallbackData{}
 	traverseHTML(doc, fallback, baseURL)
-	return fallback, nil
+	if len(fallback.Icons) > 0 {
+		// icons are a candidate only for policies opting into them
+		fallback.addCandidate(FieldImage, SourceIcon, fallback.Icons[0].URL)
+	}
+	handleJSONLD(fallback, baseURL)
+	handleProductMicrodata(&fallback.product, doc)
+	fallback.Article = fallback.article.build()
+	fallback.Product = fallback.product.build()
+	for source, values := range fallback.article.values[ArticleFieldPublished] {
+		fallback.addCandidate(FieldPublished, source, values[0])
+	}
+	return fallback
 }
 
 func traverseHTML(n *html.Node, fallback *HTMLFallbackData, baseURL string) {
@@ -33,7 +109,7 @@ func traverseHTML(n *html.Node, fallback *HTMLFallbackData, baseURL string) {
 		switch n.Data {
 		case "title":
 			if n.FirstChild != nil && n.FirstChild.Type == html.TextNode {
-				fallback.Title = strings.TrimSpace(n.FirstChild.Data)
+				fallback.addCandidate(FieldTitle, SourceHTMLTitle, strings.TrimSpace(n.FirstChild.Data))
 			}
 		case "meta":
 			handleMetaTag(n, fallback, baseURL)
@@ -41,6 +117,12 @@ func traverseHTML(n *html.Node, fallback *HTMLFallbackData, baseURL string) {
 			handleImgTag(n, fallback, baseURL)
 		case "link":
 			handleLinkTag(n, fallback, baseURL)
+		case "time":
+			handleTimeTag(&fallback.article, n)
+		case "script":
+			if getAttr(n, "type") == "application/ld+json" && n.FirstChild != nil {
+				fallback.JSONLD = append(fallback.JSONLD, parseJSONLD(n.FirstChild.Data)...)
+			}
 		}
 	}
 
@@ -55,41 +137,106 @@ func handleMetaTag(n *html.Node, fallback *HTMLFallbackData, baseURL string) {
 	if content == "" {
 		return
 	}
+	property := getAttr(n, "property")
+	handleArticleMeta(&fallback.article, property, name, content)
+	handleProductMeta(&fallback.product, property, content)
+	if name == "" && strings.HasPrefix(property, "twitter:") {
+		name = property
+	}
 	switch name {
+	case "twitter:title":
+		fallback.addCandidate(FieldTitle, SourceTwitter, content)
+	case "twitter:description":
+		fallback.addCandidate(FieldDescription, SourceTwitter, content)
 	case "description":
-		fallback.Description = content
+		fallback.addCandidate(FieldDescription, SourceMeta, content)
 	case "image", "twitter:image":
-		if fallback.Image != "" {
-			return
+		source := SourceMeta
+		if name == "twitter:image" {
+			source = SourceTwitter
 		}
-		fallback.Image = ResolveURL(baseURL, content)
+		addImage(fallback, ImageCandidate{URL: ResolveURL(baseURL, content), Source: source})
 	}
 }
 
 func handleImgTag(n *html.Node, fallback *HTMLFallbackData, baseURL string) {
-	if fallback.Image != "" {
-		return
-	}
 	src := getAttr(n, "src")
 	if src == "" {
 		return
 	}
-	fallback.Image = ResolveURL(baseURL, src)
+	addImage(fallback, ImageCandidate{
+		URL:    ResolveURL(baseURL, src),
+		Source: SourceImg,
+		Width:  atoiOrZero(getAttr(n, "width")),
+		Height: atoiOrZero(getAttr(n, "height")),
+	})
 }
 
 func handleLinkTag(n *html.Node, fallback *HTMLFallbackData, baseURL string) {
-	if fallback.Image != "" {
-		return
-	}
-	rel := getAttr(n, "rel")
 	href := getAttr(n, "href")
 	if href == "" {
 		return
 	}
-	if rel != "icon" && rel != "shortcut icon" && rel != "apple-touch-icon" {
-		return
+	rel := strings.ToLower(strings.Join(strings.Fields(getAttr(n, "rel")), " "))
+	switch rel {
+	case "icon", "shortcut icon", "apple-touch-icon", "apple-touch-icon-precomposed":
+		icon := Icon{
+			URL:   ResolveURL(baseURL, href),
+			Rel:   rel,
+			Sizes: getAttr(n, "sizes"),
+			Type:  getAttr(n, "type"),
+		}
+		fallback.Icons = append(fallback.Icons, icon)
+		fallback.ImageCandidates = append(fallback.ImageCandidates, ImageCandidate{URL: icon.URL, Source: SourceIcon})
+	case "manifest":
+		if fallback.ManifestURL == "" {
+			fallback.ManifestURL = ResolveURL(baseURL, href)
+		}
+	}
+}
+
+// addImage records an image candidate.
+func addImage(fallback *HTMLFallbackData, candidate ImageCandidate) {
+	fallback.addCandidate(FieldImage, candidate.Source, candidate.URL)
+	fallback.ImageCandidates = append(fallback.ImageCandidates, candidate)
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+}
+
+// normalizeTime parses common date formats and returns them as RFC 3339.
+// Unparseable values are returned empty.
+func normalizeTime(value string) string {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format(time.RFC3339)
+		}
 	}
-	fallback.Image = ResolveURL(baseURL, href)
+	return ""
 }
 
 func getAttr(n *html.Node, key string) string {
@@ -27,6 +27,23 @@ func TestExtractHTMLFallback_Description(t *testing.T) {
 	}
 }
 
+func TestExtractHTMLFallback_DefaultOrder(t *testing.T) {
+	htmlContent := `<html><head>
+		<meta name="description" content="Meta Description">
+		<meta name="twitter:description" content="Twitter Description">
+	</head><body><img src="/first.png"></body></html>`
+	fallback, err := ExtractHTMLFallback(strings.NewReader(htmlContent), "https://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fallback.Description != "Twitter Description" || fallback.Sources[FieldDescription] != SourceTwitter {
+		t.Errorf("got description %q from %q, want the twitter description", fallback.Description, fallback.Sources[FieldDescription])
+	}
+	if fallback.Image != "https://example.com/first.png" || fallback.Sources[FieldImage] != SourceImg {
+		t.Errorf("got image %q from %q", fallback.Image, fallback.Sources[FieldImage])
+	}
+}
+
 func TestExtractHTMLFallback_Image(t *testing.T) {
 	htmlContent := `<html><head><meta name="image" content="/img/logo.png"></head></html>`
 	fallback, err := ExtractHTMLFallback(strings.NewReader(htmlContent), "https://example.com")
@@ -60,6 +77,66 @@ func TestExtractHTMLFallback_AllFields(t *testing.T) {
 	}
 }
 
+func TestExtractHTMLFallback_ImageCandidates(t *testing.T) {
+	htmlContent := `<html><head>
+		<link rel="icon" href="/favicon.ico">
+		<meta name="twitter:image" content="/card.png">
+	</head><body><img src="/a.png" width="640" height="480"></body></html>`
+	fallback, err := ExtractHTMLFallback(strings.NewReader(htmlContent), "https://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []ImageCandidate{
+		{URL: "https://example.com/favicon.ico", Source: SourceIcon},
+		{URL: "https://example.com/card.png", Source: SourceTwitter},
+		{URL: "https://example.com/a.png", Source: SourceImg, Width: 640, Height: 480},
+	}
+	if len(fallback.ImageCandidates) != len(want) {
+		t.Fatalf("got %d candidates, want %d", len(fallback.ImageCandidates), len(want))
+	}
+	for i, c := range fallback.ImageCandidates {
+		if c != want[i] {
+			t.Errorf("candidate %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+}
+
+func TestExtractHTMLFallback_Published(t *testing.T) {
+	htmlContent := `<html><head><meta property="article:published_time" content="2024-03-01T09:30:00+09:00"></head></html>`
+	fallback, err := ExtractHTMLFallback(strings.NewReader(htmlContent), "https://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "2024-03-01T09:30:00+09:00"
+	if fallback.Published != want {
+		t.Errorf("got published %q, want %q", fallback.Published, want)
+	}
+}
+
+func TestNormalizeTime(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"RFC 3339":         {value: "2024-03-01T09:30:00Z", want: "2024-03-01T09:30:00Z"},
+		"date only":        {value: "2024-03-01", want: "2024-03-01T00:00:00Z"},
+		"without zone":     {value: "2024-03-01T09:30:00", want: "2024-03-01T09:30:00Z"},
+		"numeric zone":     {value: "2024-03-01T09:30:00+0900", want: "2024-03-01T09:30:00+09:00"},
+		"slashes":          {value: "2024/03/01", want: "2024-03-01T00:00:00Z"},
+		"RFC 1123":         {value: "Fri, 01 Mar 2024 09:30:00 GMT", want: "2024-03-01T09:30:00Z"},
+		"long month":       {value: "March 1, 2024", want: "2024-03-01T00:00:00Z"},
+		"invalid is empty": {value: "yesterday", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizeTime(tc.value); got != tc.want {
+				t.Errorf("normalizeTime(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestResolveURL(t *testing.T) {
 	tests := map[string]struct {
 		baseURL string
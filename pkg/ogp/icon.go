@@ -0,0 +1,217 @@
+package ogp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultIconSize = 64
+	// appleTouchIconSize is the size assumed for apple-touch-icon links
+	// without a sizes attribute.
+	appleTouchIconSize = 180
+	// scalableIconSize ranks sizes="any" (typically SVG) icons as a perfect fit.
+	scalableIconSize = -1
+)
+
+// Icon is a site icon declared by a <link rel=icon> variant or a web app
+// manifest.
+type Icon struct {
+	URL     string `json:"url"`
+	Rel     string `json:"rel,omitempty"`
+	Sizes   string `json:"sizes,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// Manifest holds the fields of a web app manifest.
+type Manifest struct {
+	URL        string `json:"url"`
+	Name       string `json:"name,omitempty"`
+	ShortName  string `json:"short_name,omitempty"`
+	ThemeColor string `json:"theme_color,omitempty"`
+	Icons      []Icon `json:"icons,omitempty"`
+}
+
+type manifestResponse struct {
+	Name       string `json:"name"`
+	ShortName  string `json:"short_name"`
+	ThemeColor string `json:"theme_color"`
+	Icons      []struct {
+		Src     string `json:"src"`
+		Sizes   string `json:"sizes"`
+		Type    string `json:"type"`
+		Purpose string `json:"purpose"`
+	} `json:"icons"`
+}
+
+// WithPreferredIconSize sets the icon size in pixels Result.Icon is picked for.
+// The smallest icon at least this large is preferred, then the largest one.
+func WithPreferredIconSize(size int) FetcherOption {
+	return func(f *Fetcher) { f.iconSize = size }
+}
+
+// applyIcons records the page icons and web app manifest on the result and
+// picks Result.Icon. Sites without icon links fall back to /favicon.ico. A
+// manifest icon is only picked when no page icon is large enough.
+func (f *Fetcher) applyIcons(ctx context.Context, result *Result, fallback *HTMLFallbackData, targetURL string) {
+	result.Icons = fallback.Icons
+	if len(result.Icons) == 0 {
+		if favicon := defaultFaviconURL(targetURL); favicon != "" {
+			result.Icons = []Icon{{URL: favicon, Rel: "icon"}}
+		}
+	}
+
+	icon, source := pickIcon(result.Icons, f.iconSize), SourceIcon
+	if fallback.ManifestURL != "" {
+		manifest, err := f.fetchManifest(ctx, fallback.ManifestURL)
+		if err != nil {
+			log.Debugf("failed to fetch manifest %s: %v", fallback.ManifestURL, err)
+		} else {
+			result.Manifest = manifest
+			if !iconFits(icon, f.iconSize) {
+				candidates := append(slices.Clone(result.Icons), manifest.Icons...)
+				if best := pickIcon(candidates, f.iconSize); best != nil && !slices.Contains(result.Icons, *best) {
+					icon, source = best, SourceManifest
+				}
+			}
+		}
+	}
+	if icon != nil {
+		result.Icon = icon.URL
+		result.setSource(FieldIcon, source)
+	}
+}
+
+// iconFits reports whether icon is at least preferred pixels large.
+func iconFits(icon *Icon, preferred int) bool {
+	if icon == nil {
+		return false
+	}
+	size := iconSize(icon)
+	return size == scalableIconSize || size >= preferred
+}
+
+func (f *Fetcher) fetchManifest(ctx context.Context, manifestURL string) (*Manifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+	req.Header.Set("Accept", "application/manifest+json, application/json")
+
+	body, statusCode, err := f.client.Request(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("manifest returned status %d", statusCode)
+	}
+
+	var res manifestResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	manifest := &Manifest{
+		URL:        manifestURL,
+		Name:       res.Name,
+		ShortName:  res.ShortName,
+		ThemeColor: res.ThemeColor,
+	}
+	for _, icon := range res.Icons {
+		if icon.Src == "" {
+			continue
+		}
+		manifest.Icons = append(manifest.Icons, Icon{
+			// manifest icon URLs are relative to the manifest itself
+			URL:     ResolveURL(manifestURL, icon.Src),
+			Rel:     "manifest",
+			Sizes:   icon.Sizes,
+			Type:    icon.Type,
+			Purpose: icon.Purpose,
+		})
+	}
+	return manifest, nil
+}
+
+// pickIcon returns the smallest icon at least preferred pixels large, or the
+// largest icon when none is. Scalable icons always fit; icons of unknown size
+// rank below known ones. Maskable-only manifest icons are skipped, as they are
+// cropped by the platform.
+func pickIcon(icons []Icon, preferred int) *Icon {
+	var (
+		best     *Icon
+		bestSize int
+	)
+	for i := range icons {
+		icon := &icons[i]
+		if icon.Purpose == "maskable" {
+			continue
+		}
+		size := iconSize(icon)
+		if best == nil || betterIconSize(size, bestSize, preferred) {
+			best, bestSize = icon, size
+		}
+	}
+	return best
+}
+
+func betterIconSize(size, current, preferred int) bool {
+	if current == scalableIconSize {
+		return false
+	}
+	if size == scalableIconSize {
+		return true
+	}
+	fits, currentFits := size >= preferred, current >= preferred
+	switch {
+	case fits && currentFits:
+		return size < current
+	case fits != currentFits:
+		return fits
+	default:
+		return size > current
+	}
+}
+
+// iconSize returns the largest dimension declared in the sizes attribute,
+// scalableIconSize for "any", or a guess based on rel when unspecified.
+func iconSize(icon *Icon) int {
+	largest := 0
+	for size := range strings.FieldsSeq(strings.ToLower(icon.Sizes)) {
+		if size == "any" {
+			return scalableIconSize
+		}
+		w, h, ok := strings.Cut(size, "x")
+		if !ok {
+			continue
+		}
+		wn, errW := strconv.Atoi(w)
+		hn, errH := strconv.Atoi(h)
+		if errW != nil || errH != nil {
+			continue
+		}
+		largest = max(largest, wn, hn)
+	}
+	if largest == 0 && strings.HasPrefix(icon.Rel, "apple-touch-icon") {
+		return appleTouchIconSize
+	}
+	return largest
+}
+
+func defaultFaviconURL(targetURL string) string {
+	u, err := url.Parse(targetURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/favicon.ico"}).String()
+}
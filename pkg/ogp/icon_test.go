@@ -0,0 +1,165 @@
+package ogp
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFetch_Icons(t *testing.T) {
+	const manifest = `{
+		"name": "Example App",
+		"short_name": "Example",
+		"theme_color": "#123456",
+		"icons": [
+			{"src": "icon-512.png", "sizes": "512x512", "type": "image/png"},
+			{"src": "maskable-96.png", "sizes": "96x96", "purpose": "maskable"}
+		]
+	}`
+	tests := map[string]struct {
+		links      string
+		wantIcon   string
+		wantSource Source
+	}{
+		"page icon large enough": {
+			links: `<link rel="icon" href="/favicon-16.png" sizes="16x16" type="image/png">
+				<link rel="icon" href="/favicon-32.png" sizes="32x32" type="image/png">
+				<link rel="apple-touch-icon" href="/apple.png">`,
+			wantIcon:   "https://example.com/apple.png",
+			wantSource: SourceIcon,
+		},
+		"manifest icon when page icons are small": {
+			links: `<link rel="icon" href="/favicon-16.png" sizes="16x16" type="image/png">
+				<link rel="icon" href="/favicon-32.png" sizes="32x32" type="image/png">`,
+			wantIcon:   "https://example.com/app/icon-512.png",
+			wantSource: SourceManifest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var manifestFetched bool
+			client := &fakeHTTPClient{
+				handler: func(req *http.Request) ([]byte, int, error) {
+					switch req.URL.Path {
+					case "/":
+						html := `<html><head><title>Page</title>` + tc.links +
+							`<link rel="manifest" href="/app/site.webmanifest"></head><body></body></html>`
+						return []byte(html), 200, nil
+					case "/app/site.webmanifest":
+						manifestFetched = true
+						return []byte(manifest), 200, nil
+					}
+					return []byte("Not Found"), 404, nil
+				},
+			}
+			result := NewFetcher(client, WithPreferredIconSize(64)).Fetch(t.Context(), "https://example.com/")
+
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			if result.Icon != tc.wantIcon {
+				t.Errorf("got icon %q, want %q", result.Icon, tc.wantIcon)
+			}
+			if len(result.Icons) < 2 || result.Icons[1].Sizes != "32x32" {
+				t.Errorf("unexpected icons: %+v", result.Icons)
+			}
+			if result.Image != "" {
+				t.Errorf("got image %q, want no icon as image", result.Image)
+			}
+			if !manifestFetched {
+				t.Error("expected manifest to be fetched")
+			}
+
+			m := result.Manifest
+			if m == nil {
+				t.Fatal("expected manifest")
+			}
+			if m.Name != "Example App" || m.ShortName != "Example" || m.ThemeColor != "#123456" {
+				t.Errorf("unexpected manifest: %+v", m)
+			}
+			if len(m.Icons) != 2 || m.Icons[0].URL != "https://example.com/app/icon-512.png" {
+				t.Errorf("unexpected manifest icons: %+v", m.Icons)
+			}
+			if result.Sources[FieldIcon] != tc.wantSource {
+				t.Errorf("got icon source %q, want %q", result.Sources[FieldIcon], tc.wantSource)
+			}
+		})
+	}
+}
+
+func TestFetch_Icons_PreferImgOverIcon(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			html := `<html><head><link rel="icon" href="/favicon.png"></head>
+				<body><img src="/photo.jpg"></body></html>`
+			return []byte(html), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://example.com/")
+
+	if result.Image != "https://example.com/photo.jpg" {
+		t.Errorf("got image %q, want %q", result.Image, "https://example.com/photo.jpg")
+	}
+	if result.Icon != "https://example.com/favicon.png" {
+		t.Errorf("got icon %q, want %q", result.Icon, "https://example.com/favicon.png")
+	}
+}
+
+func TestFetch_Icons_FaviconFallback(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			return []byte(`<html><head><title>No icons</title></head></html>`), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://example.com/some/page")
+
+	if result.Icon != "https://example.com/favicon.ico" {
+		t.Errorf("got icon %q, want %q", result.Icon, "https://example.com/favicon.ico")
+	}
+	if result.Image != "" {
+		t.Errorf("got image %q, want empty", result.Image)
+	}
+}
+
+func TestPickIcon(t *testing.T) {
+	tests := map[string]struct {
+		icons     []Icon
+		preferred int
+		want      string
+	}{
+		"smallest fitting": {
+			icons:     []Icon{{URL: "a", Sizes: "16x16"}, {URL: "b", Sizes: "192x192"}, {URL: "c", Sizes: "96x96"}},
+			preferred: 64,
+			want:      "c",
+		},
+		"largest when none fits": {
+			icons:     []Icon{{URL: "a", Sizes: "16x16"}, {URL: "b", Sizes: "32x32"}},
+			preferred: 64,
+			want:      "b",
+		},
+		"scalable wins": {
+			icons:     []Icon{{URL: "a", Sizes: "64x64"}, {URL: "b", Sizes: "any"}},
+			preferred: 64,
+			want:      "b",
+		},
+		"multiple sizes in one icon": {
+			icons:     []Icon{{URL: "a", Sizes: "16x16 32x32 48x48"}, {URL: "b"}},
+			preferred: 32,
+			want:      "a",
+		},
+		"skips maskable": {
+			icons:     []Icon{{URL: "a", Sizes: "64x64", Purpose: "maskable"}, {URL: "b", Sizes: "32x32"}},
+			preferred: 64,
+			want:      "b",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := pickIcon(tc.icons, tc.preferred)
+			if got == nil || got.URL != tc.want {
+				t.Errorf("pickIcon() = %+v, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/dyatlov/go-opengraph/opengraph"
+	log "github.com/sirupsen/logrus"
+	"github.com/tro3373/ogp/pkg/imageutil"
+)
+
+const (
+	// probeBytes is enough for the headers of JPEG, PNG, GIF and WebP images.
+	probeBytes         = 64 * 1024
+	maxProbeCandidates = 8
+	probeWorkers       = 4
+	minImageDimension  = 50
+	// idealImageArea is the area of the recommended 1200x630 og:image.
+	idealImageArea = 1200 * 630
+)
+
+// ImageCandidate is a potential preview image found in a page.
+type ImageCandidate struct {
+	URL    string
+	Source Source
+	// Width and Height are the dimensions declared in the markup, 0 if unknown.
+	Width  int
+	Height int
+}
+
+// ImageProbe is the result of probing an image URL.
+type ImageProbe struct {
+	Candidate ImageCandidate
+	Type      string
+	Width     int
+	Height    int
+	Score     float64
+}
+
+var imageSourceScores = map[Source]float64{
+	SourceSelector: 35,
+	SourceOG:       30,
+	SourceTwitter:  25,
+	SourceMeta:     20,
+	SourceJSONLD:   20,
+	SourceImg:      10,
+	SourceIcon:     0,
+}
+
+// selectImage probes the image candidates of a page and replaces the result
+// image with the best valid one. The image chosen by the policy is kept when
+// no candidate can be probed, e.g. SVG images.
+func (f *Fetcher) selectImage(ctx context.Context, result *Result, og *opengraph.OpenGraph, fallback *HTMLFallbackData, targetURL string, sources []Source) {
+	candidates := append(ogImageCandidates(og, targetURL), fallback.ImageCandidates...)
+	candidates = slices.DeleteFunc(candidates, func(c ImageCandidate) bool {
+		return !slices.Contains(sources, c.Source)
+	})
+
+	best := f.bestImage(ctx, candidates)
+	if best == nil {
+		return
+	}
+	result.Image = best.Candidate.URL
+	result.setSource(FieldImage, best.Candidate.Source)
+	result.ImageWidth = best.Width
+	result.ImageHeight = best.Height
+	result.ImageType = best.Type
+}
+
+func ogImageCandidates(og *opengraph.OpenGraph, baseURL string) []ImageCandidate {
+	var candidates []ImageCandidate
+	for _, img := range og.Images {
+		u := img.SecureURL
+		if u == "" {
+			u = img.URL
+		}
+		if u == "" {
+			continue
+		}
+		candidates = append(candidates, ImageCandidate{
+			URL:    ResolveURL(baseURL, u),
+			Source: SourceOG,
+			Width:  int(img.Width),  //nolint:gosec // G115: declared dimensions are small
+			Height: int(img.Height), //nolint:gosec // G115: declared dimensions are small
+		})
+	}
+	return candidates
+}
+
+// bestImage probes up to maxProbeCandidates distinct candidates concurrently
+// and returns the highest scoring valid image, or nil.
+func (f *Fetcher) bestImage(ctx context.Context, candidates []ImageCandidate) *ImageProbe {
+	candidates = uniqueCandidates(candidates)
+	if len(candidates) > maxProbeCandidates {
+		candidates = candidates[:maxProbeCandidates]
+	}
+
+	var wg sync.WaitGroup
+	probes := make([]*ImageProbe, len(candidates))
+	sem := make(chan struct{}, probeWorkers)
+	for i, c := range candidates {
+		wg.Add(1)
+		go func(i int, c ImageCandidate) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			probe, err := f.ProbeImage(ctx, c)
+			if err != nil {
+				log.Debugf("Dropping image %s: %v", c.URL, err)
+				return
+			}
+			probes[i] = probe
+		}(i, c)
+	}
+	wg.Wait()
+
+	var valid []*ImageProbe
+	for _, p := range probes {
+		if p != nil {
+			valid = append(valid, p)
+		}
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+	// stable sort keeps document order among equal scores
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].Score > valid[j].Score })
+	return valid[0]
+}
+
+// ProbeImage fetches the first bytes of an image candidate to determine its
+// MIME type and pixel dimensions. Broken, non-image and tiny images are
+// reported as errors.
+func (f *Fetcher) ProbeImage(ctx context.Context, c ImageCandidate) (*ImageProbe, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", probeBytes-1))
+
+	body, statusCode, err := f.requestPrefix(req, probeBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch: %w", err)
+	}
+	if statusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("HTTP %d", statusCode)
+	}
+
+	mimeType, ok := imageutil.Sniff(body)
+	if !ok {
+		return nil, fmt.Errorf("not an image: %s", mimeType)
+	}
+	cfg, _, err := imageutil.DecodeConfig(body)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width < minImageDimension || cfg.Height < minImageDimension {
+		return nil, fmt.Errorf("too small: %dx%d", cfg.Width, cfg.Height)
+	}
+
+	probe := &ImageProbe{Candidate: c, Type: mimeType, Width: cfg.Width, Height: cfg.Height}
+	probe.Score = scoreImage(probe)
+	return probe, nil
+}
+
+// scoreImage ranks a probed image by its source, pixel area and aspect ratio.
+// Large landscape images close to the 1.91:1 og:image ratio score highest,
+// while extreme ratios typical of banners and sprites are penalized.
+func scoreImage(p *ImageProbe) float64 {
+	score := imageSourceScores[p.Candidate.Source]
+
+	area := float64(p.Width * p.Height)
+	score += 40 * min(area/idealImageArea, 1)
+
+	ratio := float64(p.Width) / float64(p.Height)
+	switch {
+	case ratio >= 1 && ratio <= 2:
+		score += 20
+	case ratio >= 0.5 && ratio <= 3:
+		score += 10
+	case ratio > 4 || ratio < 0.25:
+		score -= 30
+	}
+	return score
+}
+
+func uniqueCandidates(candidates []ImageCandidate) []ImageCandidate {
+	seen := make(map[string]bool)
+	var unique []ImageCandidate
+	for _, c := range candidates {
+		u, err := url.Parse(c.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || seen[c.URL] {
+			continue
+		}
+		seen[c.URL] = true
+		unique = append(unique, c)
+	}
+	return unique
+}
@@ -0,0 +1,145 @@
+package ogp
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// testWebP returns the header of a lossless WebP image, which is all a probe
+// reads.
+func testWebP(w, h int) []byte {
+	bits := uint32(w-1) | uint32(h-1)<<14
+	vp8l := []byte{0x2f, byte(bits), byte(bits >> 8), byte(bits >> 16), byte(bits >> 24)}
+	chunk := append([]byte("VP8L"), byte(len(vp8l)), 0, 0, 0)
+	chunk = append(chunk, vp8l...)
+	riff := append([]byte("WEBP"), chunk...)
+	return append([]byte{'R', 'I', 'F', 'F', byte(len(riff)), 0, 0, 0}, riff...)
+}
+
+func TestFetch_ImageProbe_SelectsBestImage(t *testing.T) {
+	images := map[string][]byte{
+		"/pixel.gif":  testPNG(t, 1, 1),
+		"/banner.png": testPNG(t, 1000, 100),
+		"/hero.png":   testPNG(t, 1200, 630),
+	}
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Path == "/" {
+				html := `<html><head>
+					<title>Page</title>
+					<meta property="og:image" content="/missing.png">
+				</head><body>
+					<img src="/pixel.gif" width="1" height="1">
+					<img src="/banner.png">
+					<img src="/hero.png">
+				</body></html>`
+				return []byte(html), 200, nil
+			}
+			if req.Header.Get("Range") == "" {
+				t.Errorf("expected a Range header when probing %s", req.URL)
+			}
+			if b, ok := images[req.URL.Path]; ok {
+				return b, 206, nil
+			}
+			return []byte("Not Found"), 404, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithImageProbe(true))
+	result := fetcher.Fetch(t.Context(), "https://example.com/")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.Image != "https://example.com/hero.png" {
+		t.Errorf("got image %q, want %q", result.Image, "https://example.com/hero.png")
+	}
+	if result.ImageWidth != 1200 || result.ImageHeight != 630 || result.ImageType != "image/png" {
+		t.Errorf("got %dx%d %q, want 1200x630 image/png", result.ImageWidth, result.ImageHeight, result.ImageType)
+	}
+}
+
+func TestFetch_ImageProbe_KeepsPolicyImage(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch req.URL.Path {
+			case "/":
+				return []byte(`<html><head><meta property="og:image" content="https://example.com/logo.svg"></head></html>`), 200, nil
+			case "/logo.svg":
+				return []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="1200" height="630"></svg>`), 200, nil
+			}
+			return []byte("Not Found"), 404, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithImageProbe(true))
+	result := fetcher.Fetch(t.Context(), "https://example.com/")
+
+	if result.Image != "https://example.com/logo.svg" || result.Sources[FieldImage] != SourceOG {
+		t.Errorf("got image %q from %q, want the og:image", result.Image, result.Sources[FieldImage])
+	}
+	if result.ImageWidth != 0 || result.ImageType != "" {
+		t.Errorf("got %dx%d %q, want no probe data", result.ImageWidth, result.ImageHeight, result.ImageType)
+	}
+}
+
+func TestFetch_ImageProbe_WebP(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch req.URL.Path {
+			case "/":
+				return []byte(`<html><head><meta property="og:image" content="https://example.com/hero.webp"></head></html>`), 200, nil
+			case "/hero.webp":
+				return testWebP(1200, 630), 206, nil
+			}
+			return []byte("Not Found"), 404, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithImageProbe(true))
+	result := fetcher.Fetch(t.Context(), "https://example.com/")
+
+	if result.Image != "https://example.com/hero.webp" || result.ImageType != "image/webp" {
+		t.Errorf("got image %q of type %q", result.Image, result.ImageType)
+	}
+	if result.ImageWidth != 1200 || result.ImageHeight != 630 {
+		t.Errorf("got %dx%d, want 1200x630", result.ImageWidth, result.ImageHeight)
+	}
+}
+
+func TestScoreImage(t *testing.T) {
+	tests := map[string]struct {
+		better ImageProbe
+		worse  ImageProbe
+	}{
+		"og beats img of same size": {
+			better: ImageProbe{Candidate: ImageCandidate{Source: SourceOG}, Width: 600, Height: 315},
+			worse:  ImageProbe{Candidate: ImageCandidate{Source: SourceImg}, Width: 600, Height: 315},
+		},
+		"large beats small": {
+			better: ImageProbe{Candidate: ImageCandidate{Source: SourceImg}, Width: 1200, Height: 630},
+			worse:  ImageProbe{Candidate: ImageCandidate{Source: SourceImg}, Width: 120, Height: 63},
+		},
+		"landscape beats sprite strip": {
+			better: ImageProbe{Candidate: ImageCandidate{Source: SourceImg}, Width: 400, Height: 300},
+			worse:  ImageProbe{Candidate: ImageCandidate{Source: SourceImg}, Width: 2000, Height: 60},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if b, w := scoreImage(&tc.better), scoreImage(&tc.worse); b <= w {
+				t.Errorf("got scores %v <= %v", b, w)
+			}
+		})
+	}
+}
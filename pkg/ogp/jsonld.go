@@ -0,0 +1,131 @@
+package ogp
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// parseJSONLD decodes a <script type="application/ld+json"> block into a flat
+// list of objects, expanding top-level arrays and @graph containers.
+func parseJSONLD(text string) []map[string]any {
+	var v any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &v); err != nil {
+		return nil
+	}
+	var objects []map[string]any
+	var walk func(v any)
+	walk = func(v any) {
+		switch t := v.(type) {
+		case []any:
+			for _, item := range t {
+				walk(item)
+			}
+		case map[string]any:
+			objects = append(objects, t)
+			if graph, ok := t["@graph"]; ok {
+				walk(graph)
+			}
+		}
+	}
+	walk(v)
+	return objects
+}
+
+// handleJSONLD collects article metadata and title, description and image
+// candidates from the JSON-LD objects of the document.
+func handleJSONLD(fallback *HTMLFallbackData, baseURL string) {
+	handleArticleJSONLD(&fallback.article, fallback.JSONLD)
+	handleProductJSONLD(&fallback.product, fallback.JSONLD)
+	for _, obj := range articleJSONLDObjects(fallback.JSONLD) {
+		title := jsonLDString(obj["headline"])
+		if title == "" {
+			title = jsonLDString(obj["name"])
+		}
+		fallback.addCandidate(FieldTitle, SourceJSONLD, title)
+		fallback.addCandidate(FieldDescription, SourceJSONLD, jsonLDString(obj["description"]))
+		if image := jsonLDURL(obj["image"]); image != "" {
+			image = ResolveURL(baseURL, image)
+			fallback.addCandidate(FieldImage, SourceJSONLD, image)
+			fallback.ImageCandidates = append(fallback.ImageCandidates, ImageCandidate{URL: image, Source: SourceJSONLD})
+		}
+	}
+}
+
+// jsonLDURL returns a URL given as a string, an ImageObject-like object or an array.
+func jsonLDURL(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case map[string]any:
+		for _, key := range []string{"url", "contentUrl", "@id"} {
+			if s, ok := t[key].(string); ok && s != "" {
+				return strings.TrimSpace(s)
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if s := jsonLDURL(item); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// jsonLDTypes returns the @type values of an object.
+func jsonLDTypes(obj map[string]any) []string {
+	return jsonLDStrings(obj["@type"])
+}
+
+// hasJSONLDType reports whether obj has any of the given @type values.
+func hasJSONLDType(obj map[string]any, types ...string) bool {
+	for _, t := range jsonLDTypes(obj) {
+		if slices.Contains(types, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonLDString returns a scalar value as a string. For objects, the "name",
+// "@value" or "url" property is used; for arrays, the first usable element.
+func jsonLDString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case map[string]any:
+		for _, key := range []string{"name", "@value", "url"} {
+			if s := jsonLDString(t[key]); s != "" {
+				return s
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if s := jsonLDString(item); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// jsonLDStrings returns every value of a scalar, object or array property.
+func jsonLDStrings(v any) []string {
+	if items, ok := v.([]any); ok {
+		var values []string
+		for _, item := range items {
+			if s := jsonLDString(item); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	if s := jsonLDString(v); s != "" {
+		return []string{s}
+	}
+	return nil
+}
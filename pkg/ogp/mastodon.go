@@ -0,0 +1,188 @@
+package ogp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// mastodonStatusPatterns match the paths of status pages:
+// /@user/<id>, /@user@remote.host/<id> and /users/<user>/statuses/<id>.
+var mastodonStatusPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^/@[^/]+/(\d+)/?$`),
+	regexp.MustCompile(`^/users/[^/]+/statuses/(\d+)/?$`),
+}
+
+const (
+	mastodonInstanceCacheSize = 1024
+	mastodonInstanceTTL       = time.Hour
+)
+
+type mastodonInstance struct {
+	URI     string `json:"uri"`
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type mastodonStatus struct {
+	URL         string `json:"url"`
+	CreatedAt   string `json:"created_at"`
+	Content     string `json:"content"`
+	SpoilerText string `json:"spoiler_text"`
+	Replies     int    `json:"replies_count"`
+	Reblogs     int    `json:"reblogs_count"`
+	Favourites  int    `json:"favourites_count"`
+	Account     struct {
+		DisplayName string `json:"display_name"`
+		Username    string `json:"username"`
+		Acct        string `json:"acct"`
+		URL         string `json:"url"`
+		Avatar      string `json:"avatar"`
+	} `json:"account"`
+	MediaAttachments []struct {
+		Type        string `json:"type"`
+		URL         string `json:"url"`
+		PreviewURL  string `json:"preview_url"`
+		Description string `json:"description"`
+		Meta        struct {
+			Original struct {
+				Width  int `json:"width"`
+				Height int `json:"height"`
+			} `json:"original"`
+		} `json:"meta"`
+	} `json:"media_attachments"`
+	Card *struct {
+		Image string `json:"image"`
+	} `json:"card"`
+}
+
+// mastodonStatusID returns the status ID of a URL shaped like a Mastodon status page.
+func mastodonStatusID(targetURL string) (*url.URL, string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Host == "" {
+		return nil, "", false
+	}
+	for _, pattern := range mastodonStatusPatterns {
+		if m := pattern.FindStringSubmatch(parsed.Path); m != nil {
+			return parsed, m[1], true
+		}
+	}
+	return nil, "", false
+}
+
+// isMastodonURL checks if the URL is a status on a Mastodon-compatible
+// instance: its path must look like a status and its host must answer the
+// /api/v1/instance API. Probe results are cached per host.
+func (f *Fetcher) isMastodonURL(ctx context.Context, targetURL string) bool {
+	parsed, _, ok := mastodonStatusID(targetURL)
+	if !ok {
+		return false
+	}
+	return f.mastodonInstance(ctx, parsed) != nil
+}
+
+// mastodonInstance returns the instance metadata of the URL host, or nil
+// when it is not a Mastodon-compatible instance.
+func (f *Fetcher) mastodonInstance(ctx context.Context, u *url.URL) *mastodonInstance {
+	origin := u.Scheme + "://" + u.Host
+	if cached, ok := f.mastodonInstances.Get(origin); ok {
+		return cached
+	}
+
+	var instance *mastodonInstance
+	var resp mastodonInstance
+	if err := f.fetchJSON(ctx, origin+"/api/v1/instance", &resp, nil); err != nil {
+		log.Debugf("%s is not a Mastodon instance: %v", origin, err)
+		if errors.Is(err, errFetchFailed) {
+			// the host may be down for now: probe it again next time
+			return nil
+		}
+	} else if resp.Version != "" {
+		instance = &resp
+	}
+	f.mastodonInstances.Set(origin, instance)
+	return instance
+}
+
+func (f *Fetcher) fetchMastodon(ctx context.Context, targetURL string) *Result {
+	parsed, id, _ := mastodonStatusID(targetURL)
+	origin := parsed.Scheme + "://" + parsed.Host
+
+	var status mastodonStatus
+	if err := f.fetchJSON(ctx, origin+"/api/v1/statuses/"+id, &status, nil); err != nil {
+		log.Warnf("Mastodon API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+
+	handle := status.Account.Acct
+	if !strings.Contains(handle, "@") {
+		handle += "@" + parsed.Hostname()
+	}
+	post := &Post{
+		Author:    status.Account.DisplayName,
+		Handle:    "@" + handle,
+		AuthorURL: status.Account.URL,
+		Text:      htmlToText(status.Content),
+		Created:   normalizeTime(status.CreatedAt),
+		Replies:   status.Replies,
+		Reposts:   status.Reblogs,
+		Likes:     status.Favourites,
+	}
+	if post.Author == "" {
+		post.Author = status.Account.Username
+	}
+	for _, m := range status.MediaAttachments {
+		media := Media{
+			Type:       mastodonMediaType(m.Type),
+			URL:        m.URL,
+			PreviewURL: m.PreviewURL,
+			Width:      m.Meta.Original.Width,
+			Height:     m.Meta.Original.Height,
+			Alt:        m.Description,
+		}
+		post.Media = append(post.Media, media)
+	}
+
+	result := &Result{
+		URL:         targetURL,
+		Title:       fmt.Sprintf("%s (%s)", post.Author, post.Handle),
+		Description: excerpt(post.Text, maxExcerptLength),
+		Published:   post.Created,
+		Post:        post,
+	}
+	if status.SpoilerText != "" {
+		// keep content behind a content warning hidden
+		result.Description = status.SpoilerText
+	}
+	if instance := f.mastodonInstance(ctx, parsed); instance != nil {
+		result.SiteName = instance.Title
+	}
+	result.Image, result.ImageWidth, result.ImageHeight = previewImage(post.Media)
+	if result.Image == "" && status.Card != nil {
+		result.Image = status.Card.Image
+	}
+	if result.Image == "" {
+		result.Image = status.Account.Avatar
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
+
+// mastodonMediaType maps Mastodon attachment types to Media types.
+func mastodonMediaType(t string) string {
+	switch t {
+	case "gifv":
+		return MediaGIF
+	case "video":
+		return MediaVideo
+	case "audio":
+		return MediaAudio
+	}
+	return MediaImage
+}
@@ -0,0 +1,153 @@
+package ogp
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMastodonStatusID(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		want   string
+		wantOK bool
+	}{
+		"local account":   {url: "https://mastodon.social/@gopher/112233", want: "112233", wantOK: true},
+		"remote account":  {url: "https://mastodon.social/@gopher@example.com/112233", want: "112233", wantOK: true},
+		"activitypub uri": {url: "https://mastodon.social/users/gopher/statuses/112233", want: "112233", wantOK: true},
+		"profile":         {url: "https://mastodon.social/@gopher"},
+		"non numeric id":  {url: "https://example.com/@gopher/about"},
+		"other path":      {url: "https://example.com/blog/112233"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, got, ok := mastodonStatusID(tc.url)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("mastodonStatusID(%q) = %q, %v, want %q, %v", tc.url, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetch_Mastodon(t *testing.T) {
+	var instanceProbes int
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch req.URL.String() {
+			case "https://mastodon.example/api/v1/instance":
+				instanceProbes++
+				return []byte(`{"uri": "mastodon.example", "title": "Example Social", "version": "4.2.0"}`), 200, nil
+			case "https://mastodon.example/api/v1/statuses/112233":
+				return []byte(`{"url": "https://mastodon.example/@gopher/112233", "created_at": "2024-03-01T12:00:00.000Z",
+					"content": "<p>Hello <a href=\"https://go.dev\">go.dev</a></p><p>Second&amp;last</p>",
+					"spoiler_text": "", "replies_count": 1, "reblogs_count": 2, "favourites_count": 3,
+					"account": {"display_name": "Gopher", "username": "gopher", "acct": "gopher",
+						"url": "https://mastodon.example/@gopher", "avatar": "https://mastodon.example/avatar.png"},
+					"media_attachments": [{"type": "gifv", "url": "https://files.example/a.mp4",
+						"preview_url": "https://files.example/a.png", "description": "A dancing gopher",
+						"meta": {"original": {"width": 640, "height": 480}}}],
+					"card": null}`), 200, nil
+			}
+			return nil, 404, nil
+		},
+	}
+	fetcher := NewFetcher(client)
+
+	for range 2 {
+		result := fetcher.Fetch(t.Context(), "https://mastodon.example/@gopher/112233")
+		if result.Err != nil {
+			t.Fatalf("unexpected error: %v", result.Err)
+		}
+		if result.Title != "Gopher (@gopher@mastodon.example)" {
+			t.Errorf("got title %q", result.Title)
+		}
+		if result.Description != "Hello go.dev Second&last" {
+			t.Errorf("got description %q", result.Description)
+		}
+		if result.Image != "https://files.example/a.png" || result.ImageWidth != 640 {
+			t.Errorf("got image %q (%dx%d)", result.Image, result.ImageWidth, result.ImageHeight)
+		}
+		if result.SiteName != "Example Social" || result.Published != "2024-03-01T12:00:00Z" {
+			t.Errorf("got site name %q and published %q", result.SiteName, result.Published)
+		}
+		if result.Sources[FieldTitle] != SourceAPI {
+			t.Errorf("got title source %q, want %q", result.Sources[FieldTitle], SourceAPI)
+		}
+		want := &Post{
+			Author: "Gopher", Handle: "@gopher@mastodon.example", AuthorURL: "https://mastodon.example/@gopher",
+			Text: "Hello go.dev\n\nSecond&last", Created: "2024-03-01T12:00:00Z", Replies: 1, Reposts: 2, Likes: 3,
+			Media: []Media{{Type: MediaGIF, URL: "https://files.example/a.mp4", PreviewURL: "https://files.example/a.png",
+				Width: 640, Height: 480, Alt: "A dancing gopher"}},
+		}
+		if !reflect.DeepEqual(result.Post, want) {
+			t.Errorf("got post %+v, want %+v", result.Post, want)
+		}
+	}
+	if instanceProbes != 1 {
+		t.Errorf("got %d instance probes, want 1", instanceProbes)
+	}
+}
+
+func TestFetch_MastodonShapedNonInstance(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Path == "/api/v1/instance" {
+				return nil, 404, nil
+			}
+			return []byte(`<html><head><meta property="og:title" content="HTML Title"></head></html>`), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://blog.example/@writer/42")
+
+	if result.Title != "HTML Title" {
+		t.Errorf("got title %q, want %q", result.Title, "HTML Title")
+	}
+	if result.Post != nil {
+		t.Errorf("got post %+v, want nil", result.Post)
+	}
+}
+
+func TestFetch_MastodonProbeTransportErrorNotCached(t *testing.T) {
+	var instanceProbes int
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Path == "/api/v1/instance" {
+				instanceProbes++
+				return nil, 0, errors.New("connection reset")
+			}
+			return []byte(`<html><head><meta property="og:title" content="HTML Title"></head></html>`), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client)
+	for range 2 {
+		fetcher.Fetch(t.Context(), "https://mastodon.example/@writer/42")
+	}
+
+	if instanceProbes != 2 {
+		t.Errorf("got %d instance probes, want 2", instanceProbes)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"paragraphs": {in: "<p>one</p><p>two</p>", want: "one\n\ntwo"},
+		"line break": {in: "<p>one<br>two</p>", want: "one\ntwo"},
+		"entities":   {in: "<p>a &lt; b &amp;&amp; c</p>", want: "a < b && c"},
+		"plain text": {in: "just text", want: "just text"},
+		"unclosed":   {in: "first<p>second<p>third", want: "first\n\nsecond\n\nthird"},
+		"headings":   {in: "<h1>Title</h1><p>one</p><h2>Section</h2>two", want: "Title\n\none\n\nSection\n\ntwo"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := htmlToText(tc.in); got != tc.want {
+				t.Errorf("htmlToText(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
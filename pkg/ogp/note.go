@@ -0,0 +1,91 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const noteSiteName = "note"
+
+// notePattern matches the path of note articles: /<user>/n/<key>.
+var notePattern = regexp.MustCompile(`^/[^/]+/n/(n[0-9a-f]+)/?$`)
+
+type noteResponse struct {
+	Data struct {
+		Name        string `json:"name"`
+		Body        string `json:"body"`
+		Description string `json:"description"`
+		PublishAt   string `json:"publish_at"`
+		UpdatedAt   string `json:"updated_at"`
+		LikeCount   int    `json:"like_count"`
+		Eyecatch    string `json:"eyecatch"`
+		User        struct {
+			Nickname string `json:"nickname"`
+			URLName  string `json:"urlname"`
+		} `json:"user"`
+		HashtagNotes []struct {
+			Hashtag struct {
+				Name string `json:"name"`
+			} `json:"hashtag"`
+		} `json:"hashtag_notes"`
+	} `json:"data"`
+}
+
+// IsNoteURL checks if the URL is a note article.
+func IsNoteURL(targetURL string) bool {
+	_, ok := noteKey(targetURL)
+	return ok
+}
+
+func noteKey(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Hostname() != "note.com" {
+		return "", false
+	}
+	m := notePattern.FindStringSubmatch(parsed.Path)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+func (f *Fetcher) fetchNote(ctx context.Context, targetURL string) *Result {
+	key, _ := noteKey(targetURL)
+
+	var resp noteResponse
+	if err := f.fetchJSON(ctx, f.endpoint(EndpointNoteAPI)+"/v3/notes/"+key, &resp, nil); err != nil {
+		log.Warnf("note API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	data := resp.Data
+
+	author := data.User.Nickname
+	if author == "" {
+		author = data.User.URLName
+	}
+	tags := make([]string, 0, len(data.HashtagNotes))
+	for _, h := range data.HashtagNotes {
+		tags = append(tags, strings.TrimPrefix(h.Hashtag.Name, "#"))
+	}
+	description := data.Description
+	if description == "" {
+		description = htmlToText(data.Body)
+	}
+	result := &Result{
+		URL:         targetURL,
+		Title:       data.Name,
+		Description: excerpt(description, maxExcerptLength),
+		Image:       data.Eyecatch,
+		SiteName:    noteSiteName,
+		Article:     apiArticle(data.PublishAt, data.UpdatedAt, []string{author}, tags, data.LikeCount),
+	}
+	if result.Article != nil {
+		result.Published = result.Article.Published
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
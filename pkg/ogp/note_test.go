@@ -0,0 +1,66 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIsNoteURL(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want bool
+	}{
+		"article":    {url: "https://note.com/gopher/n/n0123456789ab", want: true},
+		"magazine":   {url: "https://note.com/gopher/m/m0123456789ab"},
+		"user page":  {url: "https://note.com/gopher"},
+		"other host": {url: "https://example.com/gopher/n/n0123456789ab"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsNoteURL(tc.url); got != tc.want {
+				t.Errorf("IsNoteURL(%q) = %v, want %v", tc.url, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_Note(t *testing.T) {
+	var gotURL string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			gotURL = req.URL.String()
+			return []byte(`{"data": {"name": "日々のこと", "body": "<p>今日は晴れ。</p>", "description": "",
+				"publish_at": "2024-03-01T12:00:00.000+09:00", "like_count": 12,
+				"eyecatch": "https://note.example/eyecatch.png", "user": {"nickname": "ごーふぁー", "urlname": "gopher"},
+				"hashtag_notes": [{"hashtag": {"name": "#日記"}}]}}`), 200, nil
+		},
+	}
+	result := NewFetcher(client, WithEndpoint(EndpointNoteAPI, "http://stub")).Fetch(t.Context(), "https://note.com/gopher/n/n0123456789ab")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if gotURL != "http://stub/v3/notes/n0123456789ab" {
+		t.Errorf("got request %q", gotURL)
+	}
+	if result.Title != "日々のこと" || result.Description != "今日は晴れ。" {
+		t.Errorf("got title %q and description %q", result.Title, result.Description)
+	}
+	if result.Image != "https://note.example/eyecatch.png" || result.SiteName != "note" {
+		t.Errorf("got image %q and site name %q", result.Image, result.SiteName)
+	}
+	want := &Article{
+		Published: "2024-03-01T12:00:00+09:00",
+		Authors:   []string{"ごーふぁー"},
+		Tags:      []string{"日記"},
+		Likes:     12,
+		Sources: map[string]Source{
+			ArticleFieldPublished: SourceAPI, ArticleFieldAuthors: SourceAPI, ArticleFieldTags: SourceAPI,
+		},
+	}
+	if !reflect.DeepEqual(result.Article, want) {
+		t.Errorf("got article %+v, want %+v", result.Article, want)
+	}
+}
@@ -0,0 +1,101 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	npmSiteName = "npm"
+	// npmPackumentBytes caps the packument read for the publish date;
+	// packages with too many versions to fit are reported without one.
+	npmPackumentBytes = 4 << 20
+)
+
+// npmManifest is the manifest of a package version.
+type npmManifest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	License     any    `json:"license"`
+	Repository  any    `json:"repository"`
+}
+
+// npmTimes is the publish time of each version in a packument, the only
+// part of it decoded.
+type npmTimes struct {
+	Time map[string]string `json:"time"`
+}
+
+// IsNPMURL checks if the URL is an npmjs.com package page.
+func IsNPMURL(targetURL string) bool {
+	_, ok := npmPackageName(targetURL)
+	return ok
+}
+
+// npmPackageName returns the package name of /package/<name> and
+// /package/@<scope>/<name> URLs, optionally followed by /v/<version>.
+func npmPackageName(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return "", false
+	}
+	if host := parsed.Hostname(); host != "www.npmjs.com" && host != "npmjs.com" {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "package" || segments[1] == "" {
+		return "", false
+	}
+	name := segments[1]
+	if strings.HasPrefix(name, "@") {
+		if len(segments) < 3 || segments[2] == "" {
+			return "", false
+		}
+		name += "/" + segments[2]
+	}
+	return name, true
+}
+
+func (f *Fetcher) fetchNPM(ctx context.Context, targetURL string) *Result {
+	name, _ := npmPackageName(targetURL)
+	base := f.endpoint(EndpointNPMRegistry) + "/" + url.PathEscape(name)
+
+	var manifest npmManifest
+	if err := f.fetchJSON(ctx, base+"/latest", &manifest, nil); err != nil {
+		log.Warnf("npm registry failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	pkg := &Package{
+		Registry:    RegistryNPM,
+		Name:        manifest.Name,
+		Version:     manifest.Version,
+		Description: manifest.Description,
+		License:     npmField(manifest.License, "type"),
+		Repository:  normalizeRepositoryURL(npmField(manifest.Repository, "url")),
+	}
+	// the version manifest has no publish date
+	var times npmTimes
+	if err := f.fetchJSONLimit(ctx, base, &times, nil, npmPackumentBytes); err != nil {
+		log.Debugf("failed to fetch npm publish times of %s: %v", name, err)
+	} else {
+		pkg.Published = normalizeTime(times.Time[manifest.Version])
+	}
+	return packageResult(targetURL, npmSiteName, pkg, f.pageImage(ctx, targetURL))
+}
+
+// npmField returns a manifest field that is either a string or an object
+// holding the value under key, like license and repository.
+func npmField(v any, key string) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]any:
+		s, _ := v[key].(string)
+		return s
+	}
+	return ""
+}
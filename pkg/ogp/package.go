@@ -0,0 +1,85 @@
+package ogp
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Package registries.
+const (
+	RegistryGo     = "go"
+	RegistryNPM    = "npm"
+	RegistryPyPI   = "pypi"
+	RegistryCrates = "crates"
+)
+
+// codeHosts are well-known code hosts, whose repository URLs are
+// https://<host>/<owner>/<repo>.
+var codeHosts = []string{"github.com", "gitlab.com", "bitbucket.org"}
+
+// scpLikeRepoPattern matches scp-like git remotes such as git@github.com:owner/repo.git.
+var scpLikeRepoPattern = regexp.MustCompile(`^[\w.-]+@([\w.-]+):(.+)$`)
+
+// Package holds metadata of the latest version of a package in a registry.
+type Package struct {
+	Registry    string `json:"registry"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+	License     string `json:"license,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+	// Published is when the version was published, RFC 3339.
+	Published string `json:"published,omitempty"`
+}
+
+// packageResult builds the result of a package page, whose preview image is
+// the og:image of the page.
+func packageResult(targetURL, siteName string, pkg *Package, image string) *Result {
+	result := &Result{
+		URL:         targetURL,
+		Title:       pkg.Name,
+		Description: pkg.Description,
+		Image:       image,
+		SiteName:    siteName,
+		Published:   pkg.Published,
+		Package:     pkg,
+	}
+	if pkg.Version != "" {
+		result.Title += " " + pkg.Version
+	}
+	if image != "" {
+		result.setSource(FieldImage, SourceOG)
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
+
+// normalizeRepositoryURL turns the repository references found in package
+// manifests (git+https, git://, scp-like and github: shorthands) into a
+// browsable https URL.
+func normalizeRepositoryURL(repo string) string {
+	repo = strings.TrimSpace(repo)
+	if repo == "" {
+		return ""
+	}
+	if owner, ok := strings.CutPrefix(repo, "github:"); ok {
+		repo = "https://github.com/" + owner
+	} else if m := scpLikeRepoPattern.FindStringSubmatch(repo); m != nil && !strings.Contains(repo, "://") {
+		repo = "https://" + m[1] + "/" + m[2]
+	}
+	repo = strings.TrimPrefix(repo, "git+")
+	for _, scheme := range []string{"git://", "ssh://git@", "git+ssh://git@", "http://"} {
+		if rest, ok := strings.CutPrefix(repo, scheme); ok {
+			repo = "https://" + rest
+		}
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+}
+
+// isCodeHostURL checks if u points to a well-known code host.
+func isCodeHostURL(u string) bool {
+	parsed, err := url.Parse(u)
+	return err == nil && slices.Contains(codeHosts, strings.TrimPrefix(parsed.Hostname(), "www."))
+}
@@ -0,0 +1,198 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPackageURLs(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		name     func(string) (string, bool)
+		wantName string
+	}{
+		"go package":        {url: "https://pkg.go.dev/github.com/spf13/cobra@v1.8.0/doc", name: goPackagePath, wantName: "github.com/spf13/cobra/doc"},
+		"go module":         {url: "https://pkg.go.dev/golang.org/x/net", name: goPackagePath, wantName: "golang.org/x/net"},
+		"go std":            {url: "https://pkg.go.dev/net/http", name: goPackagePath},
+		"go search":         {url: "https://pkg.go.dev/search?q=cobra", name: goPackagePath},
+		"npm":               {url: "https://www.npmjs.com/package/react", name: npmPackageName, wantName: "react"},
+		"npm scoped":        {url: "https://www.npmjs.com/package/@types/node/v/20.0.0", name: npmPackageName, wantName: "@types/node"},
+		"npm scope only":    {url: "https://www.npmjs.com/package/@types", name: npmPackageName},
+		"npm search":        {url: "https://www.npmjs.com/search?q=react", name: npmPackageName},
+		"pypi":              {url: "https://pypi.org/project/requests/2.31.0/", name: pypiProjectName, wantName: "requests"},
+		"pypi search":       {url: "https://pypi.org/search/?q=requests", name: pypiProjectName},
+		"crates":            {url: "https://crates.io/crates/serde", name: crateName, wantName: "serde"},
+		"crates categories": {url: "https://crates.io/categories/parsing", name: crateName},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := tc.name(tc.url)
+			if got != tc.wantName || ok != (tc.wantName != "") {
+				t.Errorf("got %q, %v, want %q", got, ok, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestNormalizeRepositoryURL(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"git+https": {in: "git+https://github.com/facebook/react.git", want: "https://github.com/facebook/react"},
+		"git":       {in: "git://github.com/user/repo.git", want: "https://github.com/user/repo"},
+		"scp-like":  {in: "git@github.com:user/repo.git", want: "https://github.com/user/repo"},
+		"git+ssh":   {in: "git+ssh://git@github.com/user/repo.git", want: "https://github.com/user/repo"},
+		"shorthand": {in: "github:user/repo", want: "https://github.com/user/repo"},
+		"https":     {in: "https://github.com/serde-rs/serde/", want: "https://github.com/serde-rs/serde"},
+		"empty":     {in: "", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizeRepositoryURL(tc.in); got != tc.want {
+				t.Errorf("normalizeRepositoryURL(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_Package(t *testing.T) {
+	responses := map[string]string{
+		"http://goproxy/github.com/spf13/cobra/doc/@latest": "",
+		"http://goproxy/github.com/spf13/cobra/@latest":     `{"Version": "v1.8.0", "Time": "2023-11-04T12:00:00Z"}`,
+		"https://pkg.go.dev/github.com/spf13/cobra/doc": `<html><head>
+			<meta property="og:image" content="/static/shared/logo/social-card.png">
+			<meta name="description" content="Package doc generates documentation."></head><body>
+			<span data-test-id="UnitHeader-licenses"><a href="?tab=licenses"> Apache-2.0 </a></span></body></html>`,
+		"http://npm/@types%2Fnode/latest": `{"name": "@types/node", "version": "20.0.0",
+			"description": "TypeScript definitions for node", "license": "MIT",
+			"repository": {"type": "git", "url": "git+https://github.com/DefinitelyTyped/DefinitelyTyped.git"}}`,
+		"http://npm/@types%2Fnode":                  `{"name": "@types/node", "time": {"20.0.0": "2024-03-01T00:00:00.000Z"}}`,
+		"https://www.npmjs.com/package/@types/node": `<meta property="og:image" content="https://static.npmjs.example/og.png">`,
+		"http://pypi/requests/json": `{"info": {"name": "requests", "version": "2.31.0", "summary": "Python HTTP for Humans.",
+			"license": "Apache 2.0", "license_expression": null, "project_urls": {"Homepage": "https://requests.readthedocs.io",
+			"Source": "https://github.com/psf/requests"}}, "urls": [{"upload_time_iso_8601": "2023-05-22T15:12:44.175259Z"}]}`,
+		"https://pypi.org/project/requests/": `<meta name="twitter:image" content="https://pypi.example/card.png">`,
+		"http://crates/crates/serde": `{"crate": {"name": "serde", "description": "A serialization framework\n",
+			"max_stable_version": "1.0.200", "max_version": "1.0.201-rc", "repository": "https://github.com/serde-rs/serde"},
+			"versions": [{"num": "1.0.201-rc", "license": "MIT"},
+				{"num": "1.0.200", "license": "MIT OR Apache-2.0", "created_at": "2024-04-29T00:00:00Z"}]}`,
+		"https://crates.io/crates/serde": `<meta property="og:image" content="https://static.crates.example/og/serde.png">`,
+	}
+	// packuments over npmPackumentBytes are not read for the publish date
+	responses["http://npm/big/latest"] = `{"name": "big", "version": "1.0.0", "license": "MIT"}`
+	responses["http://npm/big"] = `{"name": "big", "time": {"1.0.0": "2024-03-01T00:00:00.000Z"}, "readme": "` +
+		strings.Repeat("x", npmPackumentBytes) + `"}`
+
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if body, ok := responses[req.URL.String()]; ok && body != "" {
+				return []byte(body), 200, nil
+			}
+			return nil, 404, nil
+		},
+	}
+	fetcher := NewFetcher(client,
+		WithEndpoint(EndpointGoProxy, "http://goproxy"),
+		WithEndpoint(EndpointNPMRegistry, "http://npm"),
+		WithEndpoint(EndpointPyPIAPI, "http://pypi"),
+		WithEndpoint(EndpointCratesAPI, "http://crates"),
+	)
+
+	tests := map[string]struct {
+		url       string
+		wantTitle string
+		wantSite  string
+		wantImage string
+		want      Package
+	}{
+		"go": {
+			url:       "https://pkg.go.dev/github.com/spf13/cobra/doc",
+			wantImage: "https://pkg.go.dev/static/shared/logo/social-card.png",
+			wantTitle: "github.com/spf13/cobra/doc v1.8.0",
+			wantSite:  "Go Packages",
+			want: Package{Registry: RegistryGo, Name: "github.com/spf13/cobra/doc", Version: "v1.8.0",
+				Description: "Package doc generates documentation.", License: "Apache-2.0",
+				Repository: "https://github.com/spf13/cobra", Published: "2023-11-04T12:00:00Z"},
+		},
+		"npm": {
+			url:       "https://www.npmjs.com/package/@types/node",
+			wantImage: "https://static.npmjs.example/og.png",
+			wantTitle: "@types/node 20.0.0",
+			wantSite:  "npm",
+			want: Package{Registry: RegistryNPM, Name: "@types/node", Version: "20.0.0",
+				Description: "TypeScript definitions for node", License: "MIT",
+				Repository: "https://github.com/DefinitelyTyped/DefinitelyTyped", Published: "2024-03-01T00:00:00Z"},
+		},
+		"npm large packument": {
+			url:       "https://www.npmjs.com/package/big",
+			wantTitle: "big 1.0.0",
+			wantSite:  "npm",
+			want:      Package{Registry: RegistryNPM, Name: "big", Version: "1.0.0", License: "MIT"},
+		},
+		"pypi": {
+			url:       "https://pypi.org/project/requests/",
+			wantImage: "https://pypi.example/card.png",
+			wantTitle: "requests 2.31.0",
+			wantSite:  "PyPI",
+			want: Package{Registry: RegistryPyPI, Name: "requests", Version: "2.31.0",
+				Description: "Python HTTP for Humans.", License: "Apache 2.0",
+				Repository: "https://github.com/psf/requests", Published: "2023-05-22T15:12:44Z"},
+		},
+		"crates": {
+			url:       "https://crates.io/crates/serde",
+			wantImage: "https://static.crates.example/og/serde.png",
+			wantTitle: "serde 1.0.200",
+			wantSite:  "crates.io",
+			want: Package{Registry: RegistryCrates, Name: "serde", Version: "1.0.200",
+				Description: "A serialization framework", License: "MIT OR Apache-2.0",
+				Repository: "https://github.com/serde-rs/serde", Published: "2024-04-29T00:00:00Z"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := fetcher.Fetch(t.Context(), tc.url)
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			if result.Title != tc.wantTitle || result.SiteName != tc.wantSite {
+				t.Errorf("got title %q and site name %q, want %q and %q", result.Title, result.SiteName, tc.wantTitle, tc.wantSite)
+			}
+			if result.Description != tc.want.Description || result.Published != tc.want.Published {
+				t.Errorf("got description %q and published %q", result.Description, result.Published)
+			}
+			if result.Image != tc.wantImage || (tc.wantImage != "" && result.Sources[FieldImage] != SourceOG) {
+				t.Errorf("got image %q from %q, want %q from the page", result.Image, result.Sources[FieldImage], tc.wantImage)
+			}
+			if result.Package == nil {
+				t.Fatal("got nil package")
+			}
+			if !reflect.DeepEqual(*result.Package, tc.want) {
+				t.Errorf("got %+v, want %+v", *result.Package, tc.want)
+			}
+		})
+	}
+}
+
+func TestEscapeModulePath(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"upper case": {in: "github.com/BurntSushi/toml", want: "github.com/!burnt!sushi/toml"},
+		"lower case": {in: "golang.org/x/net", want: "golang.org/x/net"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := escapeModulePath(tc.in); got != tc.want {
+				t.Errorf("escapeModulePath(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
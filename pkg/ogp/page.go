@@ -0,0 +1,59 @@
+package ogp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/andybalholm/cascadia"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+)
+
+// documentBytes caps the pages providers read alongside their API; the
+// metadata they need is near the top.
+const documentBytes = 2 << 20
+
+var documentImageSelectors = []cascadia.Selector{
+	cascadia.MustCompile(`meta[property="og:image"][content]`),
+	cascadia.MustCompile(`meta[name="twitter:image"][content], meta[property="twitter:image"][content]`),
+}
+
+// pageImage returns the og:image, or twitter:image, of the page at
+// targetURL, for providers whose API has no preview image.
+func (f *Fetcher) pageImage(ctx context.Context, targetURL string) string {
+	doc, err := f.fetchDocument(ctx, targetURL)
+	if err != nil {
+		log.Debugf("failed to fetch %s: %v", targetURL, err)
+		return ""
+	}
+	return documentImage(doc, targetURL)
+}
+
+// documentImage returns the og:image, or twitter:image, of a parsed page.
+func documentImage(doc *html.Node, baseURL string) string {
+	for _, sel := range documentImageSelectors {
+		if n := cascadia.Query(doc, sel); n != nil {
+			return ResolveURL(baseURL, getAttr(n, "content"))
+		}
+	}
+	return ""
+}
+
+// fetchDocument requests an HTML page and parses its first documentBytes.
+func (f *Fetcher) fetchDocument(ctx context.Context, targetURL string) (*html.Node, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+	body, statusCode, err := f.requestPrefix(req, documentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", targetURL, err)
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d for %s", statusCode, targetURL)
+	}
+	return html.Parse(bytes.NewReader(body))
+}
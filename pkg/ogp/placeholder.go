@@ -0,0 +1,82 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tro3373/ogp/pkg/imageutil"
+)
+
+const (
+	blurHashXComponents = 4
+	blurHashYComponents = 3
+	// placeholderSampleSize bounds the image used to compute placeholders;
+	// both are low-frequency summaries, so a small sample is enough.
+	placeholderSampleSize = 64
+	// maxPlaceholderBytes caps the download of the image placeholders are
+	// computed from.
+	maxPlaceholderBytes = 20 << 20
+)
+
+// WithImagePlaceholders controls whether the preview image is downloaded to
+// compute its dominant color and BlurHash placeholder.
+func WithImagePlaceholders(enabled bool) FetcherOption {
+	return func(f *Fetcher) { f.imagePlaceholders = enabled }
+}
+
+// applyImagePlaceholders downloads the result image and records its dominant
+// color and BlurHash. Failures are logged and leave the fields empty.
+func (f *Fetcher) applyImagePlaceholders(ctx context.Context, result *Result) {
+	if result.Image == "" {
+		return
+	}
+	if err := f.computePlaceholders(ctx, result); err != nil {
+		log.Warnf("failed to compute placeholders for %s: %v", result.Image, err)
+	}
+}
+
+func (f *Fetcher) computePlaceholders(ctx context.Context, result *Result) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, result.Image, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+
+	body, statusCode, err := f.requestPrefix(req, maxPlaceholderBytes+1)
+	if err != nil {
+		return fmt.Errorf("failed to fetch: %w", err)
+	}
+	if statusCode >= http.StatusBadRequest {
+		return fmt.Errorf("HTTP %d", statusCode)
+	}
+	if len(body) > maxPlaceholderBytes {
+		return fmt.Errorf("too large (over %d bytes)", maxPlaceholderBytes)
+	}
+
+	mimeType, ok := imageutil.Sniff(body)
+	if !ok {
+		return fmt.Errorf("not an image: %s", mimeType)
+	}
+	img, _, err := imageutil.Decode(body)
+	if err != nil {
+		return err
+	}
+	if result.ImageWidth == 0 || result.ImageHeight == 0 {
+		result.ImageWidth = img.Bounds().Dx()
+		result.ImageHeight = img.Bounds().Dy()
+		result.ImageType = mimeType
+	}
+
+	sample := imageutil.Resize(img, placeholderSampleSize, placeholderSampleSize)
+	if c, ok := imageutil.DominantColor(sample); ok {
+		result.DominantColor = imageutil.HexColor(c)
+	}
+	hash, err := imageutil.BlurHash(sample, blurHashXComponents, blurHashYComponents)
+	if err != nil {
+		return err
+	}
+	result.BlurHash = hash
+	return nil
+}
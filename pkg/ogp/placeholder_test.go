@@ -0,0 +1,94 @@
+package ogp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"testing"
+)
+
+func TestFetch_ImagePlaceholders(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 120, 60))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 0x33, G: 0x66, B: 0x99, A: 255}), image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Path == "/img.png" {
+				return buf.Bytes(), 200, nil
+			}
+			html := `<html><head>
+				<meta property="og:title" content="Page">
+				<meta property="og:image" content="https://example.com/img.png">
+			</head></html>`
+			return []byte(html), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithImagePlaceholders(true))
+	result := fetcher.Fetch(t.Context(), "https://example.com/")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.DominantColor != "#336699" {
+		t.Errorf("got dominant color %q, want %q", result.DominantColor, "#336699")
+	}
+	if len(result.BlurHash) != 28 {
+		t.Errorf("got blurhash %q, want 28 characters", result.BlurHash)
+	}
+	if result.ImageWidth != 120 || result.ImageHeight != 60 || result.ImageType != "image/png" {
+		t.Errorf("got %dx%d %q, want 120x60 image/png", result.ImageWidth, result.ImageHeight, result.ImageType)
+	}
+}
+
+func TestFetch_ImagePlaceholders_BrokenImage(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Path == "/img.png" {
+				return []byte("Not Found"), 404, nil
+			}
+			return []byte(`<html><head><meta property="og:image" content="https://example.com/img.png"></head></html>`), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithImagePlaceholders(true))
+	result := fetcher.Fetch(t.Context(), "https://example.com/")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.DominantColor != "" || result.BlurHash != "" {
+		t.Errorf("got %q %q, want empty placeholders", result.DominantColor, result.BlurHash)
+	}
+}
+
+func TestFetch_ImagePlaceholders_TooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 10, 10))); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	large := append(buf.Bytes(), make([]byte, maxPlaceholderBytes)...)
+
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Path == "/img.png" {
+				return large, 200, nil
+			}
+			return []byte(`<html><head><meta property="og:image" content="https://example.com/img.png"></head></html>`), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithImagePlaceholders(true))
+	result := fetcher.Fetch(t.Context(), "https://example.com/")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.DominantColor != "" || result.BlurHash != "" {
+		t.Errorf("got %q %q, want empty placeholders", result.DominantColor, result.BlurHash)
+	}
+}
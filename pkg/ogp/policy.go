@@ -0,0 +1,126 @@
+package ogp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// policyFields are the fields whose value is chosen by a Policy.
+var policyFields = []string{FieldTitle, FieldDescription, FieldImage, FieldPublished}
+
+// defaultSourceOrder is the order sources are tried in for each field.
+var defaultSourceOrder = map[string][]Source{
+	FieldTitle:       {SourceSelector, SourceOG, SourceTwitter, SourceJSONLD, SourceHTMLTitle},
+	FieldDescription: {SourceSelector, SourceOG, SourceTwitter, SourceJSONLD, SourceMeta, SourceContent},
+	FieldImage:       {SourceSelector, SourceOG, SourceTwitter, SourceMeta, SourceJSONLD, SourceImg},
+	FieldPublished:   {SourceSelector, SourceOG, SourceJSONLD, SourceDublinCore, SourceMeta, SourceTime},
+}
+
+// optionalSources are accepted in a policy order but not used by default:
+// favicons make poor preview images.
+var optionalSources = map[string][]Source{
+	FieldImage: {SourceIcon},
+}
+
+// knownSources returns every source a policy may use for field.
+func knownSources(field string) []Source {
+	return slices.Concat(defaultSourceOrder[field], optionalSources[field])
+}
+
+// Policy controls which sources fill the title, description, image and
+// published fields of a Result, and in which order they are tried.
+type Policy struct {
+	// Order lists, per field, the sources to use, most preferred first.
+	// Sources missing from a field's list are never used for it; fields
+	// missing from Order keep the default order.
+	Order map[string][]Source
+	// Disabled sources are never used for any field.
+	Disabled []Source
+}
+
+// WithPolicy sets the extraction policy applied to every page.
+func WithPolicy(p Policy) FetcherOption {
+	return func(f *Fetcher) { f.policy = p }
+}
+
+// WithDomainPolicy sets an extraction policy for a host and its subdomains.
+// Its field orders replace those of the global policy and its disabled
+// sources are added to the global ones.
+func WithDomainPolicy(domain string, p Policy) FetcherOption {
+	return func(f *Fetcher) {
+		if f.domainPolicies == nil {
+			f.domainPolicies = make(map[string]Policy)
+		}
+		f.domainPolicies[strings.ToLower(strings.TrimPrefix(domain, "."))] = p
+	}
+}
+
+// DefaultSourceOrder returns the default source order of a field.
+func DefaultSourceOrder(field string) []Source {
+	return slices.Clone(defaultSourceOrder[field])
+}
+
+// Validate reports unknown fields and sources in the policy.
+func (p Policy) Validate() error {
+	for field, sources := range p.Order {
+		if _, ok := defaultSourceOrder[field]; !ok {
+			return fmt.Errorf("unknown policy field %q (want one of %s)", field, strings.Join(policyFields, ", "))
+		}
+		for _, source := range sources {
+			if !slices.Contains(knownSources(field), source) {
+				return fmt.Errorf("unknown source %q for field %q", source, field)
+			}
+		}
+	}
+	for _, source := range p.Disabled {
+		if !slices.ContainsFunc(policyFields, func(field string) bool {
+			return slices.Contains(knownSources(field), source)
+		}) {
+			return fmt.Errorf("unknown source %q", source)
+		}
+	}
+	return nil
+}
+
+// sourceOrder returns the effective source order of each policy field for a URL.
+func (f *Fetcher) sourceOrder(targetURL string) map[string][]Source {
+	policies := []Policy{f.policy}
+	if domain, ok := f.domainPolicy(targetURL); ok {
+		policies = append(policies, domain)
+	}
+
+	orders := make(map[string][]Source, len(policyFields))
+	for _, field := range policyFields {
+		order := defaultSourceOrder[field]
+		for _, p := range policies {
+			if o, ok := p.Order[field]; ok {
+				order = o
+			}
+		}
+		orders[field] = slices.DeleteFunc(slices.Clone(order), func(s Source) bool {
+			return slices.ContainsFunc(policies, func(p Policy) bool { return slices.Contains(p.Disabled, s) })
+		})
+	}
+	return orders
+}
+
+// domainPolicy returns the policy of the most specific domain matching the URL host.
+func (f *Fetcher) domainPolicy(targetURL string) (Policy, bool) {
+	for _, host := range hostSuffixes(targetURL) {
+		if p, ok := f.domainPolicies[host]; ok {
+			return p, true
+		}
+	}
+	return Policy{}, false
+}
+
+// pickSource returns the value of the first source in order that has one.
+func pickSource(order []Source, values map[Source]string) (string, Source) {
+	for _, source := range order {
+		if v := values[source]; v != "" {
+			return v, source
+		}
+	}
+	return "", ""
+}
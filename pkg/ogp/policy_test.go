@@ -0,0 +1,146 @@
+package ogp
+
+import (
+	"net/http"
+	"testing"
+)
+
+const policyPage = `<html><head>
+	<title>HTML Title</title>
+	<meta property="og:title" content="OG Title">
+	<meta name="twitter:title" content="Twitter Title">
+	<meta name="description" content="Meta Description">
+	<script type="application/ld+json">{"@type": "Article", "headline": "JSON-LD Title",
+		"description": "JSON-LD Description", "image": {"@type": "ImageObject", "url": "/ld.png"}}</script>
+	<link rel="icon" href="/icon.png">
+</head><body><img src="/first.png"></body></html>`
+
+func TestFetch_Policy(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		opts     []FetcherOption
+		want     map[string]string
+		wantSrcs map[string]Source
+	}{
+		"default order": {
+			url: "https://example.com/",
+			want: map[string]string{
+				FieldTitle:       "OG Title",
+				FieldDescription: "JSON-LD Description",
+				FieldImage:       "https://example.com/ld.png",
+			},
+			wantSrcs: map[string]Source{FieldTitle: SourceOG, FieldDescription: SourceJSONLD, FieldImage: SourceJSONLD},
+		},
+		"custom order": {
+			url: "https://example.com/",
+			opts: []FetcherOption{WithPolicy(Policy{Order: map[string][]Source{
+				FieldTitle:       {SourceHTMLTitle, SourceOG},
+				FieldDescription: {SourceMeta},
+			}})},
+			want: map[string]string{
+				FieldTitle:       "HTML Title",
+				FieldDescription: "Meta Description",
+				FieldImage:       "https://example.com/ld.png",
+			},
+			wantSrcs: map[string]Source{FieldTitle: SourceHTMLTitle, FieldDescription: SourceMeta, FieldImage: SourceJSONLD},
+		},
+		"disabled sources": {
+			url:  "https://example.com/",
+			opts: []FetcherOption{WithPolicy(Policy{Disabled: []Source{SourceOG, SourceJSONLD, SourceImg}})},
+			want: map[string]string{
+				FieldTitle:       "Twitter Title",
+				FieldDescription: "Meta Description",
+				FieldImage:       "",
+			},
+			wantSrcs: map[string]Source{FieldTitle: SourceTwitter, FieldDescription: SourceMeta},
+		},
+		"icon opted into": {
+			url: "https://example.com/",
+			opts: []FetcherOption{WithPolicy(Policy{
+				Order: map[string][]Source{FieldImage: {SourceIcon}},
+			})},
+			want:     map[string]string{FieldImage: "https://example.com/icon.png"},
+			wantSrcs: map[string]Source{FieldImage: SourceIcon},
+		},
+		"domain override applies to subdomains": {
+			url: "https://www.example.com/",
+			opts: []FetcherOption{
+				WithPolicy(Policy{Disabled: []Source{SourceJSONLD}}),
+				WithDomainPolicy("example.com", Policy{
+					Order:    map[string][]Source{FieldTitle: {SourceHTMLTitle}},
+					Disabled: []Source{SourceImg, SourceIcon},
+				}),
+			},
+			want: map[string]string{
+				FieldTitle:       "HTML Title",
+				FieldDescription: "Meta Description",
+				FieldImage:       "",
+			},
+			wantSrcs: map[string]Source{FieldTitle: SourceHTMLTitle, FieldDescription: SourceMeta},
+		},
+		"domain override ignored for other hosts": {
+			url: "https://other.org/",
+			opts: []FetcherOption{
+				WithDomainPolicy("example.com", Policy{Order: map[string][]Source{FieldTitle: {SourceHTMLTitle}}}),
+			},
+			want:     map[string]string{FieldTitle: "OG Title"},
+			wantSrcs: map[string]Source{FieldTitle: SourceOG},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeHTTPClient{
+				handler: func(req *http.Request) ([]byte, int, error) {
+					return []byte(policyPage), 200, nil
+				},
+			}
+			result := NewFetcher(client, tc.opts...).Fetch(t.Context(), tc.url)
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			got := result.fieldValues()
+			for field, want := range tc.want {
+				if got[field] != want {
+					t.Errorf("got %s %q, want %q", field, got[field], want)
+				}
+			}
+			for field, want := range tc.wantSrcs {
+				if result.Sources[field] != want {
+					t.Errorf("got %s source %q, want %q", field, result.Sources[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := map[string]struct {
+		policy  Policy
+		wantErr bool
+	}{
+		"empty": {policy: Policy{}},
+		"valid": {policy: Policy{Order: map[string][]Source{FieldImage: {SourceOG, SourceMeta}}, Disabled: []Source{SourceIcon}}},
+		"unknown field": {
+			policy:  Policy{Order: map[string][]Source{"subtitle": {SourceOG}}},
+			wantErr: true,
+		},
+		"source not valid for field": {
+			policy:  Policy{Order: map[string][]Source{FieldTitle: {SourceImg}}},
+			wantErr: true,
+		},
+		"unknown disabled source": {
+			policy:  Policy{Disabled: []Source{"rss"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
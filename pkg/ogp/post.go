@@ -0,0 +1,140 @@
+package ogp
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+
+// Post holds metadata of a social media or discussion post.
+type Post struct {
+	Author    string `json:"author,omitempty"`
+	Handle    string `json:"handle,omitempty"`
+	AuthorURL string `json:"author_url,omitempty"`
+	// Community is where the post was made, e.g. a subreddit.
+	Community string `json:"community,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Created   string `json:"created,omitempty"`
+	// Replies counts replies, or comments on discussion sites.
+	Replies int `json:"replies,omitempty"`
+	// Reposts counts boosts, reblogs and retweets.
+	Reposts int `json:"reposts,omitempty"`
+	// Likes counts favourites and likes.
+	Likes int `json:"likes,omitempty"`
+	// Score is the net vote count on discussion sites.
+	Score int     `json:"score,omitempty"`
+	Media []Media `json:"media,omitempty"`
+	// Linked is the preview of the page the post links to. Without
+	// WithLinkedPreviews, discussion posts only set its URL.
+	Linked *Result `json:"linked,omitempty"`
+}
+
+// Media types.
+const (
+	MediaImage = "image"
+	MediaVideo = "video"
+	MediaGIF   = "gif"
+	MediaAudio = "audio"
+)
+
+// Media is an image, video, GIF or audio attached to a post.
+type Media struct {
+	Type string `json:"type"`
+	// URL is the image, or the best encoding of videos and GIFs.
+	URL string `json:"url"`
+	// PreviewURL is a still image of videos and GIFs, or a smaller image.
+	PreviewURL string `json:"preview_url,omitempty"`
+	Width      int    `json:"width,omitempty"`
+	Height     int    `json:"height,omitempty"`
+	Alt        string `json:"alt,omitempty"`
+	// Variants lists the encodings of videos and GIFs, highest bitrate first.
+	Variants []MediaVariant `json:"variants,omitempty"`
+}
+
+// previewImage returns the first image, or still of a video or GIF, among media.
+func previewImage(media []Media) (string, int, int) {
+	for _, m := range media {
+		switch {
+		case m.Type == MediaImage:
+			return m.URL, m.Width, m.Height
+		case m.PreviewURL != "":
+			return m.PreviewURL, m.Width, m.Height
+		}
+	}
+	return "", 0, 0
+}
+
+// textBlockElements are the elements htmlToText separates with a blank line.
+var textBlockElements = []string{"p", "pre", "blockquote", "h1", "h2", "h3", "h4", "h5", "h6"}
+
+// htmlToText converts post HTML to plain text, keeping paragraphs and line breaks.
+func htmlToText(s string) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		// paragraphs may be unclosed, as in Hacker News comments, so blocks
+		// are separated from the text before them too
+		block := n.Type == html.ElementNode && slices.Contains(textBlockElements, n.Data)
+		switch {
+		case n.Type == html.TextNode:
+			sb.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			sb.WriteString("\n")
+		case block:
+			sb.WriteString("\n\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			sb.WriteString("\n\n")
+		}
+	}
+	walk(doc)
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+// WithLinkedPreviews enables fetching the preview of the page a discussion
+// post links to into Post.Linked.
+func WithLinkedPreviews(enabled bool) FetcherOption {
+	return func(f *Fetcher) { f.linkedPreviews = enabled }
+}
+
+// applyLinked records the page a post links to and, with linked previews
+// enabled, fetches its preview, using its image when the post has none.
+func (f *Fetcher) applyLinked(ctx context.Context, result *Result, linkURL string) {
+	if linkURL == "" {
+		return
+	}
+	result.Post.Linked = &Result{URL: linkURL}
+	if !f.linkedPreviews {
+		return
+	}
+	linked := f.fetchLinkedContent(ctx, linkURL)
+	if linked == nil {
+		return
+	}
+	result.Post.Linked = linked
+	if result.Image == "" && linked.Image != "" {
+		result.Image = linked.Image
+		result.setSource(FieldImage, SourceLinkedContent)
+	}
+	if result.Description == "" && linked.Description != "" {
+		result.Description = linked.Description
+		result.setSource(FieldDescription, SourceLinkedContent)
+	}
+}
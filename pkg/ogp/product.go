@@ -0,0 +1,385 @@
+package ogp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Product fields recorded in Product.Sources.
+const (
+	ProductFieldName         = "name"
+	ProductFieldBrand        = "brand"
+	ProductFieldSKU          = "sku"
+	ProductFieldPrice        = "price"
+	ProductFieldCurrency     = "currency"
+	ProductFieldAvailability = "availability"
+	ProductFieldRating       = "rating"
+	ProductFieldReviewCount  = "review_count"
+)
+
+// productSourcePriority orders the sources of product metadata, most trusted first.
+var productSourcePriority = []Source{SourceSelector, SourceJSONLD, SourceMicrodata, SourceOG}
+
+var productJSONLDTypes = []string{"Product", "ProductGroup", "IndividualProduct", "ProductModel"}
+
+// currencySymbols map price prefixes and suffixes to ISO 4217 codes. Longer
+// symbols come first, and a bare "$" is taken as USD.
+var currencySymbols = []struct{ symbol, code string }{
+	{"R$", "BRL"}, {"CA$", "CAD"}, {"C$", "CAD"}, {"AU$", "AUD"}, {"A$", "AUD"}, {"MX$", "MXN"}, {"US$", "USD"},
+	{"¥", "JPY"}, {"￥", "JPY"}, {"円", "JPY"}, {"€", "EUR"}, {"£", "GBP"}, {"₹", "INR"}, {"₩", "KRW"}, {"$", "USD"},
+}
+
+var (
+	pricePattern  = regexp.MustCompile(`\d[\d.,]*`)
+	numberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+)
+
+// Product holds the commercial metadata of a product page. Price is a
+// decimal number without thousands separators, Currency an ISO 4217 code and
+// Availability a schema.org ItemAvailability name such as InStock.
+type Product struct {
+	Name         string            `json:"name,omitempty"`
+	Brand        string            `json:"brand,omitempty"`
+	SKU          string            `json:"sku,omitempty"`
+	Price        string            `json:"price,omitempty"`
+	Currency     string            `json:"currency,omitempty"`
+	Availability string            `json:"availability,omitempty"`
+	Rating       float64           `json:"rating,omitempty"`
+	ReviewCount  int               `json:"review_count,omitempty"`
+	Sources      map[string]Source `json:"sources,omitempty"`
+}
+
+// productCollector gathers product metadata values per field and source
+// while traversing a document. The first value of each source wins.
+type productCollector struct {
+	values map[string]map[Source]string
+	// symbolCurrencies holds the currencies implied by price symbols, used
+	// only when no source states the currency.
+	symbolCurrencies map[Source]string
+}
+
+func (c *productCollector) add(field string, source Source, value string) {
+	value = collapseSpace(value)
+	if value == "" {
+		return
+	}
+	if c.values == nil {
+		c.values = make(map[string]map[Source]string)
+	}
+	if c.values[field] == nil {
+		c.values[field] = make(map[Source]string)
+	}
+	if _, ok := c.values[field][source]; !ok {
+		c.values[field][source] = value
+	}
+}
+
+// addPrice records a price, and the currency its symbol implies.
+func (c *productCollector) addPrice(source Source, value string) {
+	price, currency := parsePrice(value)
+	c.add(ProductFieldPrice, source, price)
+	if currency == "" {
+		return
+	}
+	if c.symbolCurrencies == nil {
+		c.symbolCurrencies = make(map[Source]string)
+	}
+	if _, ok := c.symbolCurrencies[source]; !ok {
+		c.symbolCurrencies[source] = currency
+	}
+}
+
+// build returns the collected product, or nil when no price, availability,
+// rating or SKU was found: a name or brand alone does not make a product page.
+func (c *productCollector) build() *Product {
+	p := &Product{Sources: make(map[string]Source)}
+	fields := map[string]*string{
+		ProductFieldName:         &p.Name,
+		ProductFieldBrand:        &p.Brand,
+		ProductFieldSKU:          &p.SKU,
+		ProductFieldPrice:        &p.Price,
+		ProductFieldCurrency:     &p.Currency,
+		ProductFieldAvailability: &p.Availability,
+	}
+	for field, dst := range fields {
+		if v, source := pickSource(productSourcePriority, c.values[field]); v != "" {
+			*dst, p.Sources[field] = v, source
+		}
+	}
+	if v, source := pickSource(productSourcePriority, c.values[ProductFieldRating]); v != "" {
+		if rating, err := strconv.ParseFloat(strings.ReplaceAll(numberPattern.FindString(v), ",", "."), 64); err == nil {
+			p.Rating, p.Sources[ProductFieldRating] = rating, source
+		}
+	}
+	if v, source := pickSource(productSourcePriority, c.values[ProductFieldReviewCount]); v != "" {
+		if n, err := strconv.Atoi(strings.NewReplacer(",", "", ".", "").Replace(numberPattern.FindString(v))); err == nil {
+			p.ReviewCount, p.Sources[ProductFieldReviewCount] = n, source
+		}
+	}
+	if p.Currency == "" {
+		if v, source := pickSource(productSourcePriority, c.symbolCurrencies); v != "" {
+			p.Currency, p.Sources[ProductFieldCurrency] = v, source
+		}
+	}
+	p.Currency = strings.ToUpper(p.Currency)
+	p.Availability = normalizeAvailability(p.Availability)
+	if p.Price == "" && p.Availability == "" && p.Rating == 0 && p.SKU == "" {
+		return nil
+	}
+	return p
+}
+
+// handleProductMeta collects product metadata from og:price:*, product:*
+// and og:availability <meta> properties.
+func handleProductMeta(c *productCollector, property, content string) {
+	switch property {
+	case "og:price:amount", "product:price:amount":
+		c.addPrice(SourceOG, content)
+	case "og:price:currency", "product:price:currency":
+		c.add(ProductFieldCurrency, SourceOG, content)
+	case "og:availability", "product:availability":
+		c.add(ProductFieldAvailability, SourceOG, content)
+	case "og:brand", "product:brand":
+		c.add(ProductFieldBrand, SourceOG, content)
+	case "product:retailer_item_id":
+		c.add(ProductFieldSKU, SourceOG, content)
+	}
+}
+
+// handleProductJSONLD collects product metadata from JSON-LD Product objects
+// and their Offer, AggregateOffer and AggregateRating.
+func handleProductJSONLD(c *productCollector, objects []map[string]any) {
+	for _, obj := range objects {
+		if !hasJSONLDType(obj, productJSONLDTypes...) {
+			continue
+		}
+		c.add(ProductFieldName, SourceJSONLD, jsonLDString(obj["name"]))
+		c.add(ProductFieldBrand, SourceJSONLD, jsonLDString(obj["brand"]))
+		c.add(ProductFieldSKU, SourceJSONLD, jsonLDString(obj["sku"]))
+		for _, offer := range jsonLDObjects(obj["offers"]) {
+			price := jsonLDString(offer["price"])
+			if price == "" {
+				price = jsonLDString(offer["lowPrice"])
+			}
+			currency := jsonLDString(offer["priceCurrency"])
+			for _, spec := range jsonLDObjects(offer["priceSpecification"]) {
+				if price == "" {
+					price = jsonLDString(spec["price"])
+				}
+				if currency == "" {
+					currency = jsonLDString(spec["priceCurrency"])
+				}
+			}
+			c.addPrice(SourceJSONLD, price)
+			c.add(ProductFieldCurrency, SourceJSONLD, currency)
+			c.add(ProductFieldAvailability, SourceJSONLD, jsonLDString(offer["availability"]))
+		}
+		for _, rating := range jsonLDObjects(obj["aggregateRating"]) {
+			c.add(ProductFieldRating, SourceJSONLD, jsonLDString(rating["ratingValue"]))
+			count := jsonLDString(rating["reviewCount"])
+			if count == "" {
+				count = jsonLDString(rating["ratingCount"])
+			}
+			c.add(ProductFieldReviewCount, SourceJSONLD, count)
+		}
+	}
+}
+
+// jsonLDObjects returns the objects of a property holding an object or an array.
+func jsonLDObjects(v any) []map[string]any {
+	switch t := v.(type) {
+	case map[string]any:
+		return []map[string]any{t}
+	case []any:
+		var objects []map[string]any
+		for _, item := range t {
+			if obj, ok := item.(map[string]any); ok {
+				objects = append(objects, obj)
+			}
+		}
+		return objects
+	}
+	return nil
+}
+
+// handleProductMicrodata collects product metadata from schema.org Product
+// microdata items. Properties of nested items are named after the property
+// holding the item, e.g. offers.price.
+func handleProductMicrodata(c *productCollector, doc *html.Node) {
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode && hasAttr(n, "itemscope") && isProductItemType(getAttr(n, "itemtype")) {
+			props := make(map[string]string)
+			collectMicrodata(n, "", props)
+			addProductMicrodata(c, props)
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			find(child)
+		}
+	}
+	find(doc)
+}
+
+func isProductItemType(itemType string) bool {
+	for _, t := range productJSONLDTypes {
+		if strings.HasSuffix(itemType, "schema.org/"+t) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectMicrodata records the first value of each property below the item n.
+func collectMicrodata(n *html.Node, prefix string, props map[string]string) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		prop := getAttr(child, "itemprop")
+		if prop == "" {
+			collectMicrodata(child, prefix, props)
+			continue
+		}
+		name := prefix + prop
+		if hasAttr(child, "itemscope") {
+			if isProductItemType(getAttr(child, "itemtype")) {
+				// variants and related products describe other products
+				continue
+			}
+			collectMicrodata(child, name+".", props)
+			continue
+		}
+		if _, ok := props[name]; !ok {
+			props[name] = microdataValue(child)
+		}
+		collectMicrodata(child, prefix, props)
+	}
+}
+
+// microdataValue returns the value of a property element.
+func microdataValue(n *html.Node) string {
+	if hasAttr(n, "content") {
+		return getAttr(n, "content")
+	}
+	switch n.Data {
+	case "a", "link":
+		return getAttr(n, "href")
+	case "img":
+		return getAttr(n, "src")
+	case "meta":
+		return getAttr(n, "content")
+	case "time":
+		if v := getAttr(n, "datetime"); v != "" {
+			return v
+		}
+	}
+	return nodeText(n)
+}
+
+func addProductMicrodata(c *productCollector, props map[string]string) {
+	first := func(names ...string) string {
+		for _, name := range names {
+			if v := props[name]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	c.add(ProductFieldName, SourceMicrodata, first("name"))
+	c.add(ProductFieldBrand, SourceMicrodata, first("brand.name", "brand"))
+	c.add(ProductFieldSKU, SourceMicrodata, first("sku"))
+	c.addPrice(SourceMicrodata, first("offers.price", "offers.lowPrice", "price"))
+	c.add(ProductFieldCurrency, SourceMicrodata, first("offers.priceCurrency", "priceCurrency"))
+	c.add(ProductFieldAvailability, SourceMicrodata, first("offers.availability", "availability"))
+	c.add(ProductFieldRating, SourceMicrodata, first("aggregateRating.ratingValue", "ratingValue"))
+	c.add(ProductFieldReviewCount, SourceMicrodata, first("aggregateRating.reviewCount", "aggregateRating.ratingCount", "reviewCount"))
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePrice normalizes a price such as "$1,299.00", "¥1,980" or "12,50 €"
+// to a plain decimal number, and returns the currency of its symbol if any.
+func parsePrice(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	var currency string
+	for _, c := range currencySymbols {
+		if strings.Contains(s, c.symbol) {
+			currency = c.code
+			break
+		}
+	}
+	amount := pricePattern.FindString(s)
+	if amount == "" {
+		return "", currency
+	}
+	amount = strings.TrimRight(amount, ".,")
+	lastDot, lastComma := strings.LastIndex(amount, "."), strings.LastIndex(amount, ",")
+	decimal := -1
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal = max(lastDot, lastComma)
+	default:
+		// a lone separator is a decimal point, as in 12,50 and 12.50, unless
+		// three digits follow, as in 1,980 and 1.299
+		sep := max(lastDot, lastComma)
+		if sep >= 0 && strings.Count(amount, amount[sep:sep+1]) == 1 && len(amount)-sep-1 != 3 {
+			decimal = sep
+		}
+	}
+	var sb strings.Builder
+	for i, r := range amount {
+		switch {
+		case i == decimal:
+			sb.WriteByte('.')
+		case r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String(), currency
+}
+
+// normalizeAvailability returns the schema.org ItemAvailability name of an
+// availability such as "http://schema.org/InStock", "in stock" or "oos".
+func normalizeAvailability(s string) string {
+	if s == "" {
+		return ""
+	}
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		s = s[i+1:]
+	}
+	key := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s))
+	switch key {
+	case "instock", "available":
+		return "InStock"
+	case "outofstock", "oos":
+		return "OutOfStock"
+	case "soldout":
+		return "SoldOut"
+	case "preorder":
+		return "PreOrder"
+	case "backorder":
+		return "BackOrder"
+	case "discontinued":
+		return "Discontinued"
+	case "limitedavailability":
+		return "LimitedAvailability"
+	case "instoreonly":
+		return "InStoreOnly"
+	case "onlineonly":
+		return "OnlineOnly"
+	case "presale":
+		return "PreSale"
+	}
+	return s
+}
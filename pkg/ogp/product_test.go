@@ -0,0 +1,128 @@
+package ogp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := map[string]struct {
+		in           string
+		wantPrice    string
+		wantCurrency string
+	}{
+		"plain":            {in: "1980", wantPrice: "1980"},
+		"decimal":          {in: "19.99", wantPrice: "19.99"},
+		"dollars":          {in: "$1,299.00", wantPrice: "1299.00", wantCurrency: "USD"},
+		"yen":              {in: "￥1,980", wantPrice: "1980", wantCurrency: "JPY"},
+		"yen suffix":       {in: "1,980円（税込）", wantPrice: "1980", wantCurrency: "JPY"},
+		"decimal comma":    {in: "12,50 €", wantPrice: "12.50", wantCurrency: "EUR"},
+		"european grouped": {in: "1.299,00 €", wantPrice: "1299.00", wantCurrency: "EUR"},
+		"dot thousands":    {in: "1.299 €", wantPrice: "1299", wantCurrency: "EUR"},
+		"repeated dots":    {in: "1.299.000", wantPrice: "1299000"},
+		"reais":            {in: "R$ 1.299,90", wantPrice: "1299.90", wantCurrency: "BRL"},
+		"canadian dollars": {in: "CA$24.99", wantPrice: "24.99", wantCurrency: "CAD"},
+		"no number":        {in: "free"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			price, currency := parsePrice(tc.in)
+			if price != tc.wantPrice || currency != tc.wantCurrency {
+				t.Errorf("parsePrice(%q) = %q, %q, want %q, %q", tc.in, price, currency, tc.wantPrice, tc.wantCurrency)
+			}
+		})
+	}
+}
+
+func TestNormalizeAvailability(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"schema url": {in: "https://schema.org/InStock", want: "InStock"},
+		"og value":   {in: "instock", want: "InStock"},
+		"words":      {in: "out of stock", want: "OutOfStock"},
+		"oos":        {in: "oos", want: "OutOfStock"},
+		"preorder":   {in: "pre-order", want: "PreOrder"},
+		"unknown":    {in: "Ships in 3 days", want: "Ships in 3 days"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := normalizeAvailability(tc.in); got != tc.want {
+				t.Errorf("normalizeAvailability(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtractHTMLFallback_Product(t *testing.T) {
+	tests := map[string]struct {
+		html string
+		want *Product
+	}{
+		"open graph": {
+			html: `<meta property="og:price:amount" content="1,980">
+				<meta property="og:price:currency" content="jpy">
+				<meta property="product:availability" content="in stock">
+				<meta property="product:brand" content="Gopher Inc.">`,
+			want: &Product{Brand: "Gopher Inc.", Price: "1980", Currency: "JPY", Availability: "InStock",
+				Sources: map[string]Source{ProductFieldBrand: SourceOG, ProductFieldPrice: SourceOG,
+					ProductFieldCurrency: SourceOG, ProductFieldAvailability: SourceOG}},
+		},
+		"stated currency beats the symbol": {
+			html: `<meta property="og:price:amount" content="$24.99">
+				<meta property="og:price:currency" content="CAD">`,
+			want: &Product{Price: "24.99", Currency: "CAD",
+				Sources: map[string]Source{ProductFieldPrice: SourceOG, ProductFieldCurrency: SourceOG}},
+		},
+		"json-ld": {
+			html: `<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Product",
+				"name": "Gopher Plush", "brand": {"@type": "Brand", "name": "Gopher Inc."}, "sku": "GP-1",
+				"offers": [{"@type": "Offer", "price": 24.5, "priceCurrency": "USD", "availability": "https://schema.org/PreOrder"}],
+				"aggregateRating": {"@type": "AggregateRating", "ratingValue": "4.6", "reviewCount": "1,024"}}</script>
+				<meta property="og:price:amount" content="30">`,
+			want: &Product{Name: "Gopher Plush", Brand: "Gopher Inc.", SKU: "GP-1", Price: "24.5", Currency: "USD",
+				Availability: "PreOrder", Rating: 4.6, ReviewCount: 1024,
+				Sources: map[string]Source{ProductFieldName: SourceJSONLD, ProductFieldBrand: SourceJSONLD,
+					ProductFieldSKU: SourceJSONLD, ProductFieldPrice: SourceJSONLD, ProductFieldCurrency: SourceJSONLD,
+					ProductFieldAvailability: SourceJSONLD, ProductFieldRating: SourceJSONLD, ProductFieldReviewCount: SourceJSONLD}},
+		},
+		"microdata": {
+			html: `<div itemscope itemtype="https://schema.org/Product">
+				<h1 itemprop="name">Gopher Mug</h1>
+				<div itemprop="brand" itemscope itemtype="https://schema.org/Brand"><span itemprop="name">Go Shop</span></div>
+				<div itemprop="offers" itemscope itemtype="https://schema.org/Offer">
+					<span itemprop="price" content="12.00">$12</span><meta itemprop="priceCurrency" content="USD">
+					<link itemprop="availability" href="https://schema.org/OutOfStock">
+				</div>
+				<div itemprop="aggregateRating" itemscope itemtype="https://schema.org/AggregateRating">
+					<span itemprop="ratingValue">4</span> (<span itemprop="reviewCount">12</span> reviews)
+				</div>
+				<div itemprop="isSimilarTo" itemscope itemtype="https://schema.org/Product"><span itemprop="name">Other</span></div>
+			</div>`,
+			want: &Product{Name: "Gopher Mug", Brand: "Go Shop", Price: "12.00", Currency: "USD",
+				Availability: "OutOfStock", Rating: 4, ReviewCount: 12,
+				Sources: map[string]Source{ProductFieldName: SourceMicrodata, ProductFieldBrand: SourceMicrodata,
+					ProductFieldPrice: SourceMicrodata, ProductFieldCurrency: SourceMicrodata,
+					ProductFieldAvailability: SourceMicrodata, ProductFieldRating: SourceMicrodata, ProductFieldReviewCount: SourceMicrodata}},
+		},
+		"article": {
+			html: `<meta property="og:type" content="article"><meta property="article:author" content="Gopher">`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			fallback, err := ExtractHTMLFallback(strings.NewReader("<html><head>"+tc.html+"</head></html>"), "https://example.com/")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(fallback.Product, tc.want) {
+				t.Errorf("got %+v, want %+v", fallback.Product, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package ogp
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxExcerptLength is the length in runes descriptions built from post or
+// issue bodies are cut to.
+const maxExcerptLength = 300
+
+// provider fetches metadata for URLs of a specific site through its API
+// instead of the generic HTML path.
+type provider struct {
+	name  string
+	match func(f *Fetcher, ctx context.Context, targetURL string) bool
+	fetch func(f *Fetcher, ctx context.Context, targetURL string) *Result
+}
+
+// providers are tried in order; the first one matching a URL handles it.
+var providers = []provider{
+	{name: "twitter", match: matchURL(IsTwitterURL), fetch: (*Fetcher).fetchTwitter},
+	{name: "youtube", match: matchURL(IsYouTubeURL), fetch: (*Fetcher).fetchYouTube},
+	{name: "github", match: matchFetcher((*Fetcher).isGitHubURL), fetch: (*Fetcher).fetchGitHub},
+	{name: "bluesky", match: matchURL(IsBlueskyURL), fetch: (*Fetcher).fetchBluesky},
+	{name: "wikipedia", match: matchURL(IsWikipediaURL), fetch: (*Fetcher).fetchWikipedia},
+	{name: "qiita", match: matchURL(IsQiitaURL), fetch: (*Fetcher).fetchQiita},
+	{name: "zenn", match: matchURL(IsZennURL), fetch: (*Fetcher).fetchZenn},
+	{name: "note", match: matchURL(IsNoteURL), fetch: (*Fetcher).fetchNote},
+	{name: "go", match: matchURL(IsGoPackageURL), fetch: (*Fetcher).fetchGoPackage},
+	{name: "npm", match: matchURL(IsNPMURL), fetch: (*Fetcher).fetchNPM},
+	{name: "pypi", match: matchURL(IsPyPIURL), fetch: (*Fetcher).fetchPyPI},
+	{name: "crates", match: matchURL(IsCratesURL), fetch: (*Fetcher).fetchCrate},
+	{name: "amazon", match: matchURL(IsAmazonURL), fetch: (*Fetcher).fetchAmazon},
+	{name: "reddit", match: matchURL(IsRedditURL), fetch: (*Fetcher).fetchReddit},
+	{name: "hackernews", match: matchURL(IsHackerNewsURL), fetch: (*Fetcher).fetchHackerNews},
+	// mastodon probes the host, so it comes after providers matching by host
+	{name: "mastodon", match: (*Fetcher).isMastodonURL, fetch: (*Fetcher).fetchMastodon},
+}
+
+// matchURL adapts a URL predicate that needs no fetcher configuration.
+func matchURL(match func(targetURL string) bool) func(*Fetcher, context.Context, string) bool {
+	return func(_ *Fetcher, _ context.Context, targetURL string) bool { return match(targetURL) }
+}
+
+// matchFetcher adapts a predicate that reads the fetcher configuration but
+// makes no requests.
+func matchFetcher(match func(f *Fetcher, targetURL string) bool) func(*Fetcher, context.Context, string) bool {
+	return func(f *Fetcher, _ context.Context, targetURL string) bool { return match(f, targetURL) }
+}
+
+// providerFor returns the provider handling targetURL, or nil for the generic path.
+func (f *Fetcher) providerFor(ctx context.Context, targetURL string) *provider {
+	for i := range providers {
+		if providers[i].match(f, ctx, targetURL) {
+			return &providers[i]
+		}
+	}
+	return nil
+}
+
+// excerpt collapses the whitespace of s and cuts it to at most n runes.
+func excerpt(s string, n int) string {
+	s = collapseSpace(s)
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
@@ -0,0 +1,113 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const pypiSiteName = "PyPI"
+
+// pypiRepositoryLabels are project URL labels pointing to the source code, most specific first.
+var pypiRepositoryLabels = []string{"source", "source code", "repository", "code", "github", "homepage"}
+
+type pypiResponse struct {
+	Info struct {
+		Name              string            `json:"name"`
+		Version           string            `json:"version"`
+		Summary           string            `json:"summary"`
+		License           string            `json:"license"`
+		LicenseExpression string            `json:"license_expression"`
+		Classifiers       []string          `json:"classifiers"`
+		HomePage          string            `json:"home_page"`
+		ProjectURLs       map[string]string `json:"project_urls"`
+	} `json:"info"`
+	// URLs are the files of the latest version.
+	URLs []struct {
+		UploadTime string `json:"upload_time_iso_8601"`
+	} `json:"urls"`
+}
+
+// IsPyPIURL checks if the URL is a pypi.org project page.
+func IsPyPIURL(targetURL string) bool {
+	_, ok := pypiProjectName(targetURL)
+	return ok
+}
+
+// pypiProjectName returns the project name of /project/<name>/[<version>/] URLs.
+func pypiProjectName(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Hostname() != "pypi.org" {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "project" || segments[1] == "" {
+		return "", false
+	}
+	return segments[1], true
+}
+
+func (f *Fetcher) fetchPyPI(ctx context.Context, targetURL string) *Result {
+	name, _ := pypiProjectName(targetURL)
+
+	var resp pypiResponse
+	if err := f.fetchJSON(ctx, f.endpoint(EndpointPyPIAPI)+"/"+url.PathEscape(name)+"/json", &resp, nil); err != nil {
+		log.Warnf("PyPI API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	info := resp.Info
+
+	pkg := &Package{
+		Registry:    RegistryPyPI,
+		Name:        info.Name,
+		Version:     info.Version,
+		Description: info.Summary,
+		License:     pypiLicense(info.LicenseExpression, info.License, info.Classifiers),
+		Repository:  pypiRepository(info.ProjectURLs, info.HomePage),
+	}
+	if len(resp.URLs) > 0 {
+		pkg.Published = normalizeTime(resp.URLs[0].UploadTime)
+	}
+	return packageResult(targetURL, pypiSiteName, pkg, f.pageImage(ctx, targetURL))
+}
+
+// pypiLicense prefers the SPDX expression, then a short license field, then
+// the license classifier. The license field often holds the full text.
+func pypiLicense(expression, license string, classifiers []string) string {
+	if expression != "" {
+		return expression
+	}
+	if license != "" && len(license) <= 64 && !strings.Contains(license, "\n") {
+		return license
+	}
+	for _, c := range classifiers {
+		if strings.HasPrefix(c, "License :: ") {
+			parts := strings.Split(c, " :: ")
+			return parts[len(parts)-1]
+		}
+	}
+	return ""
+}
+
+// pypiRepository returns the source code URL among the project URLs.
+func pypiRepository(projectURLs map[string]string, homePage string) string {
+	labeled := make(map[string]string, len(projectURLs))
+	for label, u := range projectURLs {
+		labeled[strings.ToLower(label)] = u
+	}
+	for _, label := range pypiRepositoryLabels {
+		u := labeled[label]
+		if u == "" {
+			continue
+		}
+		if label != "homepage" || isCodeHostURL(u) {
+			return u
+		}
+	}
+	if isCodeHostURL(homePage) {
+		return homePage
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const qiitaSiteName = "Qiita"
+
+// qiitaItemPattern matches the path of Qiita articles: /<user>/items/<id>.
+var qiitaItemPattern = regexp.MustCompile(`^/[^/]+/items/([0-9a-f]+)/?$`)
+
+type qiitaItemResponse struct {
+	Title        string `json:"title"`
+	RenderedBody string `json:"rendered_body"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+	LikesCount   int    `json:"likes_count"`
+	Tags         []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+	User struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
+// IsQiitaURL checks if the URL is a Qiita article.
+func IsQiitaURL(targetURL string) bool {
+	_, ok := qiitaItemID(targetURL)
+	return ok
+}
+
+func qiitaItemID(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Hostname() != "qiita.com" {
+		return "", false
+	}
+	m := qiitaItemPattern.FindStringSubmatch(parsed.Path)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+func (f *Fetcher) fetchQiita(ctx context.Context, targetURL string) *Result {
+	id, _ := qiitaItemID(targetURL)
+
+	var item qiitaItemResponse
+	if err := f.fetchJSON(ctx, f.endpoint(EndpointQiitaAPI)+"/items/"+id, &item, nil); err != nil {
+		log.Warnf("Qiita API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+
+	author := item.User.Name
+	if author == "" {
+		author = item.User.ID
+	}
+	tags := make([]string, 0, len(item.Tags))
+	for _, tag := range item.Tags {
+		tags = append(tags, tag.Name)
+	}
+	result := &Result{
+		URL:         targetURL,
+		Title:       item.Title,
+		Description: excerpt(htmlToText(item.RenderedBody), maxExcerptLength),
+		SiteName:    qiitaSiteName,
+		Article:     apiArticle(item.CreatedAt, item.UpdatedAt, []string{author}, tags, item.LikesCount),
+	}
+	// the API has no image: the page's og:image is a card of the article
+	if result.Image = f.pageImage(ctx, targetURL); result.Image != "" {
+		result.setSource(FieldImage, SourceOG)
+	}
+	if result.Article != nil {
+		result.Published = result.Article.Published
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
@@ -0,0 +1,74 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIsQiitaURL(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want bool
+	}{
+		"item":       {url: "https://qiita.com/gopher/items/0123456789abcdef0123", want: true},
+		"user page":  {url: "https://qiita.com/gopher"},
+		"tag page":   {url: "https://qiita.com/tags/go"},
+		"other host": {url: "https://example.com/gopher/items/0123456789abcdef0123"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsQiitaURL(tc.url); got != tc.want {
+				t.Errorf("IsQiitaURL(%q) = %v, want %v", tc.url, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_Qiita(t *testing.T) {
+	var gotURL string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Host == "qiita.com" {
+				return []byte(`<html><head><meta property="og:image" content="https://qiita-user-contents.example/card.png"></head></html>`), 200, nil
+			}
+			gotURL = req.URL.String()
+			return []byte(`{"title": "Go入門", "rendered_body": "<h1>はじめに</h1><p>Goを学ぶ。</p>",
+				"created_at": "2024-03-01T12:00:00+09:00", "updated_at": "2024-03-05T12:00:00+09:00",
+				"likes_count": 42, "tags": [{"name": "Go"}, {"name": "入門"}],
+				"user": {"id": "gopher", "name": "", "profile_image_url": "https://qiita.example/gopher.png"}}`), 200, nil
+		},
+	}
+	result := NewFetcher(client, WithEndpoint(EndpointQiitaAPI, "http://stub")).Fetch(t.Context(), "https://qiita.com/gopher/items/0123456789abcdef0123")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if gotURL != "http://stub/items/0123456789abcdef0123" {
+		t.Errorf("got request %q", gotURL)
+	}
+	if result.Title != "Go入門" || result.Description != "はじめに Goを学ぶ。" {
+		t.Errorf("got title %q and description %q", result.Title, result.Description)
+	}
+	if result.Image != "https://qiita-user-contents.example/card.png" || result.Sources[FieldImage] != SourceOG {
+		t.Errorf("got image %q from %q, want the page og:image", result.Image, result.Sources[FieldImage])
+	}
+	if result.SiteName != "Qiita" || result.Published != "2024-03-01T12:00:00+09:00" {
+		t.Errorf("got site name %q and published %q", result.SiteName, result.Published)
+	}
+	want := &Article{
+		Published: "2024-03-01T12:00:00+09:00",
+		Modified:  "2024-03-05T12:00:00+09:00",
+		Authors:   []string{"gopher"},
+		Tags:      []string{"Go", "入門"},
+		Likes:     42,
+		Sources: map[string]Source{
+			ArticleFieldPublished: SourceAPI, ArticleFieldModified: SourceAPI,
+			ArticleFieldAuthors: SourceAPI, ArticleFieldTags: SourceAPI,
+		},
+	}
+	if !reflect.DeepEqual(result.Article, want) {
+		t.Errorf("got article %+v, want %+v", result.Article, want)
+	}
+}
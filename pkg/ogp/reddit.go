@@ -0,0 +1,123 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const redditSiteName = "Reddit"
+
+var redditHosts = []string{"reddit.com", "www.reddit.com", "old.reddit.com", "new.reddit.com", "np.reddit.com"}
+
+type redditListing struct {
+	Data struct {
+		Children []struct {
+			Data redditPost `json:"data"`
+		} `json:"children"`
+	} `json:"data"`
+}
+
+type redditPost struct {
+	Title       string  `json:"title"`
+	Subreddit   string  `json:"subreddit_name_prefixed"`
+	Author      string  `json:"author"`
+	Score       int     `json:"score"`
+	NumComments int     `json:"num_comments"`
+	CreatedUTC  float64 `json:"created_utc"`
+	URL         string  `json:"url"`
+	IsSelf      bool    `json:"is_self"`
+	Selftext    string  `json:"selftext"`
+	Preview     *struct {
+		Images []struct {
+			Source struct {
+				URL    string `json:"url"`
+				Width  int    `json:"width"`
+				Height int    `json:"height"`
+			} `json:"source"`
+		} `json:"images"`
+	} `json:"preview"`
+}
+
+// IsRedditURL checks if the URL is a Reddit post: /r/<sub>/comments/<id> or redd.it/<id>.
+func IsRedditURL(targetURL string) bool {
+	_, ok := redditPostID(targetURL)
+	return ok
+}
+
+func redditPostID(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return "", false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	switch {
+	case host == "redd.it":
+		return segments[0], len(segments) == 1 && segments[0] != ""
+	case slices.Contains(redditHosts, host):
+		if len(segments) >= 2 && segments[0] == "comments" {
+			return segments[1], segments[1] != ""
+		}
+		if len(segments) >= 4 && segments[0] == "r" && segments[2] == "comments" {
+			return segments[3], segments[3] != ""
+		}
+	}
+	return "", false
+}
+
+func (f *Fetcher) fetchReddit(ctx context.Context, targetURL string) *Result {
+	id, _ := redditPostID(targetURL)
+
+	post, err := f.redditPost(ctx, id)
+	if err != nil {
+		log.Warnf("Reddit API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+
+	created := time.Unix(int64(post.CreatedUTC), 0).UTC().Format(time.RFC3339)
+	result := &Result{
+		URL:         targetURL,
+		Title:       post.Title,
+		Description: excerpt(post.Selftext, maxExcerptLength),
+		SiteName:    redditSiteName,
+		Published:   created,
+		Post: &Post{
+			Author:    post.Author,
+			Handle:    "u/" + post.Author,
+			AuthorURL: "https://www.reddit.com/user/" + post.Author,
+			Community: post.Subreddit,
+			Text:      post.Selftext,
+			Created:   created,
+			Replies:   post.NumComments,
+			Score:     post.Score,
+		},
+	}
+	if post.Preview != nil && len(post.Preview.Images) > 0 {
+		source := post.Preview.Images[0].Source
+		result.Image, result.ImageWidth, result.ImageHeight = source.URL, source.Width, source.Height
+	}
+	result.recordSources(SourceAPI)
+	if !post.IsSelf {
+		f.applyLinked(ctx, result, post.URL)
+	}
+	return result
+}
+
+func (f *Fetcher) redditPost(ctx context.Context, id string) (*redditPost, error) {
+	// raw_json=1 keeps & in URLs unescaped
+	reqURL := fmt.Sprintf("%s/comments/%s.json?raw_json=1&limit=1", f.endpoint(EndpointRedditAPI), url.PathEscape(id))
+	var listings []redditListing
+	if err := f.fetchJSON(ctx, reqURL, &listings, nil); err != nil {
+		return nil, err
+	}
+	if len(listings) == 0 || len(listings[0].Data.Children) == 0 {
+		return nil, fmt.Errorf("post %s not found", id)
+	}
+	return &listings[0].Data.Children[0].Data, nil
+}
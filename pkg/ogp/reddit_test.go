@@ -0,0 +1,68 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRedditPostID(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		want   string
+		wantOK bool
+	}{
+		"post":       {url: "https://www.reddit.com/r/golang/comments/1abcde/go_122_is_released/", want: "1abcde", wantOK: true},
+		"old reddit": {url: "https://old.reddit.com/r/golang/comments/1abcde/", want: "1abcde", wantOK: true},
+		"comments":   {url: "https://reddit.com/comments/1abcde", want: "1abcde", wantOK: true},
+		"short link": {url: "https://redd.it/1abcde", want: "1abcde", wantOK: true},
+		"subreddit":  {url: "https://www.reddit.com/r/golang/"},
+		"other host": {url: "https://example.com/r/golang/comments/1abcde/"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := redditPostID(tc.url)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("redditPostID(%q) = %q, %v, want %q, %v", tc.url, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetch_Reddit(t *testing.T) {
+	var gotURL string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			gotURL = req.URL.String()
+			return []byte(`[{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": {
+				"title": "Go 1.22 is released", "subreddit_name_prefixed": "r/golang", "author": "gopher",
+				"score": 512, "num_comments": 64, "created_utc": 1707264000.0, "is_self": false,
+				"url": "https://go.dev/blog/go1.22", "selftext": "",
+				"preview": {"images": [{"source": {"url": "https://preview.redd.it/a.png?width=1200&s=x",
+					"width": 1200, "height": 630}}]}}}]}}, {"kind": "Listing", "data": {"children": []}}]`), 200, nil
+		},
+	}
+	result := NewFetcher(client, WithEndpoint(EndpointRedditAPI, "http://stub")).
+		Fetch(t.Context(), "https://www.reddit.com/r/golang/comments/1abcde/go_122_is_released/")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if gotURL != "http://stub/comments/1abcde.json?raw_json=1&limit=1" {
+		t.Errorf("got request %q", gotURL)
+	}
+	if result.Title != "Go 1.22 is released" || result.SiteName != "Reddit" || result.Published != "2024-02-07T00:00:00Z" {
+		t.Errorf("got title %q, site name %q and published %q", result.Title, result.SiteName, result.Published)
+	}
+	if result.Image != "https://preview.redd.it/a.png?width=1200&s=x" || result.ImageWidth != 1200 {
+		t.Errorf("got image %q (%dx%d)", result.Image, result.ImageWidth, result.ImageHeight)
+	}
+	want := &Post{
+		Author: "gopher", Handle: "u/gopher", AuthorURL: "https://www.reddit.com/user/gopher", Community: "r/golang",
+		Created: "2024-02-07T00:00:00Z", Replies: 64, Score: 512, Linked: &Result{URL: "https://go.dev/blog/go1.22"},
+	}
+	if !reflect.DeepEqual(result.Post, want) {
+		t.Errorf("got post %+v, want %+v", result.Post, want)
+	}
+}
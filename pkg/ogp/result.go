@@ -2,9 +2,44 @@ package ogp
 
 // Result holds the extracted OGP metadata for a URL.
 type Result struct {
-	URL         string `json:"url"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Image       string `json:"image"`
-	Err         error  `json:"-"`
+	URL           string    `json:"url"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Image         string    `json:"image"`
+	ImageWidth    int       `json:"image_width,omitempty"`
+	ImageHeight   int       `json:"image_height,omitempty"`
+	ImageType     string    `json:"image_type,omitempty"`
+	DominantColor string    `json:"dominant_color,omitempty"`
+	BlurHash      string    `json:"blurhash,omitempty"`
+	SiteName      string    `json:"site_name,omitempty"`
+	Lang          string    `json:"lang,omitempty"`
+	Icon          string    `json:"icon,omitempty"`
+	Icons         []Icon    `json:"icons,omitempty"`
+	Manifest      *Manifest `json:"manifest,omitempty"`
+	Published     string    `json:"published,omitempty"`
+	Article       *Article  `json:"article,omitempty"`
+	Content       *Content  `json:"content,omitempty"`
+	Video         *Video    `json:"video,omitempty"`
+	GitHub        *GitHub   `json:"github,omitempty"`
+	Post          *Post     `json:"post,omitempty"`
+	Package       *Package  `json:"package,omitempty"`
+	Product       *Product  `json:"product,omitempty"`
+	// Extra holds the custom fields extracted by per-host Rules.
+	Extra      map[string]string `json:"extra,omitempty"`
+	LocalImage *LocalFile        `json:"local_image,omitempty"`
+	LocalIcon  *LocalFile        `json:"local_icon,omitempty"`
+	// Sources records where each field value came from, keyed by field name.
+	Sources map[string]Source `json:"sources,omitempty"`
+	// Score rates from 0 to 100 how complete the preview is.
+	Score int   `json:"score"`
+	Err   error `json:"-"`
+}
+
+// LocalFile describes a downloaded copy of a remote file.
+type LocalFile struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+	Type      string `json:"type"`
+	Thumbnail string `json:"thumbnail,omitempty"`
 }
@@ -0,0 +1,196 @@
+package ogp
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+)
+
+// SelectorRule extracts a value from the elements matching a CSS selector:
+// the Attr attribute, or the text when Attr is empty. Image rules default to
+// the src attribute.
+type SelectorRule struct {
+	Selector string
+	Attr     string
+}
+
+// Rules are the CSS-selector extraction rules of a host. They take
+// precedence over every other source; Fields values are reported in
+// Result.Extra.
+type Rules struct {
+	Title       SelectorRule
+	Description SelectorRule
+	Image       SelectorRule
+	Author      SelectorRule
+	Date        SelectorRule
+	Fields      map[string]SelectorRule
+}
+
+type compiledRule struct {
+	sel  cascadia.Matcher
+	attr string
+}
+
+type compiledRules struct {
+	title, description, image, author, date *compiledRule
+	fields                                  map[string]*compiledRule
+}
+
+// WithRules sets the extraction rules for a host and its subdomains.
+// Rules with an invalid selector are logged and ignored.
+func WithRules(host string, r Rules) FetcherOption {
+	return func(f *Fetcher) {
+		compiled, err := r.compile()
+		if err != nil {
+			log.Warnf("ignoring extraction rules for %s: %v", host, err)
+			return
+		}
+		if f.rules == nil {
+			f.rules = make(map[string]*compiledRules)
+		}
+		f.rules[strings.ToLower(strings.TrimPrefix(host, "."))] = compiled
+	}
+}
+
+// Validate reports invalid selectors in the rules.
+func (r Rules) Validate() error {
+	_, err := r.compile()
+	return err
+}
+
+func (r Rules) compile() (*compiledRules, error) {
+	var err error
+	compile := func(name string, rule SelectorRule) *compiledRule {
+		if rule.Selector == "" || err != nil {
+			return nil
+		}
+		sel, perr := cascadia.ParseGroup(rule.Selector)
+		if perr != nil {
+			err = fmt.Errorf("%s: invalid selector %q: %w", name, rule.Selector, perr)
+			return nil
+		}
+		return &compiledRule{sel: sel, attr: strings.ToLower(rule.Attr)}
+	}
+
+	c := &compiledRules{
+		title:       compile("title", r.Title),
+		description: compile("description", r.Description),
+		author:      compile("author", r.Author),
+		date:        compile("date", r.Date),
+		fields:      make(map[string]*compiledRule, len(r.Fields)),
+	}
+	image := r.Image
+	if image.Attr == "" {
+		image.Attr = "src"
+	}
+	c.image = compile("image", image)
+	for _, name := range slices.Sorted(maps.Keys(r.Fields)) {
+		if rule := compile(name, r.Fields[name]); rule != nil {
+			c.fields[name] = rule
+		}
+	}
+	return c, err
+}
+
+// values returns the values of the elements matching the rule.
+func (r *compiledRule) values(doc *html.Node) []string {
+	var values []string
+	for _, n := range cascadia.QueryAll(doc, r.sel) {
+		v := nodeText(n)
+		if r.attr != "" {
+			v = strings.TrimSpace(getAttr(n, r.attr))
+		}
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func (r *compiledRule) first(doc *html.Node) string {
+	if r == nil {
+		return ""
+	}
+	if values := r.values(doc); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// rulesFor returns the rules of the most specific host matching the URL.
+func (f *Fetcher) rulesFor(targetURL string) *compiledRules {
+	for _, host := range hostSuffixes(targetURL) {
+		if r, ok := f.rules[host]; ok {
+			return r
+		}
+	}
+	return nil
+}
+
+// hostSuffixes returns the host of a URL followed by its parent domains.
+func hostSuffixes(targetURL string) []string {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return nil
+	}
+	var hosts []string
+	for host := strings.ToLower(parsed.Hostname()); host != ""; _, host, _ = strings.Cut(host, ".") {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// applyRules adds the values extracted by rules to the fallback candidates
+// and article, and returns the custom field values.
+func applyRules(doc *html.Node, fallback *HTMLFallbackData, rules *compiledRules, baseURL string) map[string]string {
+	fallback.addCandidate(FieldTitle, SourceSelector, rules.title.first(doc))
+	fallback.addCandidate(FieldDescription, SourceSelector, rules.description.first(doc))
+	if image := rules.image.first(doc); image != "" {
+		image = ResolveURL(baseURL, image)
+		fallback.addCandidate(FieldImage, SourceSelector, image)
+		fallback.ImageCandidates = append(fallback.ImageCandidates, ImageCandidate{URL: image, Source: SourceSelector})
+	}
+
+	if date := rules.date.first(doc); date != "" {
+		if published := normalizeTime(date); published != "" {
+			fallback.addCandidate(FieldPublished, SourceSelector, published)
+			ensureArticle(fallback)
+		} else {
+			log.Debugf("ignoring unparseable date %q", date)
+		}
+	}
+	if rules.author != nil {
+		if authors := rules.author.values(doc); len(authors) > 0 {
+			a := ensureArticle(fallback)
+			a.Authors = uniqueStrings(authors)
+			a.Sources[ArticleFieldAuthors] = SourceSelector
+		}
+	}
+
+	if len(rules.fields) == 0 {
+		return nil
+	}
+	extra := make(map[string]string, len(rules.fields))
+	for name, rule := range rules.fields {
+		if v := rule.first(doc); v != "" {
+			extra[name] = v
+		}
+	}
+	if len(extra) == 0 {
+		return nil
+	}
+	return extra
+}
+
+func ensureArticle(fallback *HTMLFallbackData) *Article {
+	if fallback.Article == nil {
+		fallback.Article = &Article{Sources: make(map[string]Source)}
+	}
+	return fallback.Article
+}
@@ -0,0 +1,92 @@
+package ogp
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+const rulesPage = `<html><head>
+	<title>Intranet</title>
+	<meta property="og:title" content="OG Title">
+</head><body>
+	<h1 class="page-title">Quarterly report</h1>
+	<div class="byline"><a>Alice</a> and <a>Bob</a></div>
+	<span class="date" data-ts="2024-03-01T09:00:00Z">1 March</span>
+	<img class="hero" src="/hero.png">
+	<span class="dept">Finance</span>
+	<span>Carol</span>
+</body></html>`
+
+var testRules = Rules{
+	Title:  SelectorRule{Selector: "h1.page-title"},
+	Image:  SelectorRule{Selector: "img.hero"},
+	Author: SelectorRule{Selector: ".byline a"},
+	Date:   SelectorRule{Selector: ".date", Attr: "data-ts"},
+	Fields: map[string]SelectorRule{
+		"department": {Selector: ".dept"},
+		"teamLead":   {Selector: "span.dept + span:not([class])"},
+		"missing":    {Selector: ".nothing"},
+	},
+}
+
+func TestFetch_Rules(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			return []byte(rulesPage), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithRules("intranet.example.com", testRules))
+	result := fetcher.Fetch(t.Context(), "https://wiki.intranet.example.com/report")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.Title != "Quarterly report" {
+		t.Errorf("got title %q, want %q", result.Title, "Quarterly report")
+	}
+	if result.Image != "https://wiki.intranet.example.com/hero.png" {
+		t.Errorf("got image %q, want %q", result.Image, "https://wiki.intranet.example.com/hero.png")
+	}
+	if result.Published != "2024-03-01T09:00:00Z" {
+		t.Errorf("got published %q, want %q", result.Published, "2024-03-01T09:00:00Z")
+	}
+	for _, field := range []string{FieldTitle, FieldImage, FieldPublished} {
+		if result.Sources[field] != SourceSelector {
+			t.Errorf("got %s source %q, want %q", field, result.Sources[field], SourceSelector)
+		}
+	}
+	if result.Article == nil || !slices.Equal(result.Article.Authors, []string{"Alice", "Bob"}) {
+		t.Errorf("got article %+v, want authors Alice and Bob", result.Article)
+	}
+	if len(result.Extra) != 2 || result.Extra["department"] != "Finance" || result.Extra["teamLead"] != "Carol" {
+		t.Errorf("got extra %v, want department Finance and teamLead Carol", result.Extra)
+	}
+}
+
+func TestFetch_RulesOtherHost(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			return []byte(rulesPage), 200, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithRules("intranet.example.com", testRules))
+	result := fetcher.Fetch(t.Context(), "https://example.com/report")
+
+	if result.Title != "OG Title" {
+		t.Errorf("got title %q, want %q", result.Title, "OG Title")
+	}
+	if result.Extra != nil {
+		t.Errorf("got extra %v, want nil", result.Extra)
+	}
+}
+
+func TestRules_Validate(t *testing.T) {
+	if err := testRules.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	invalid := Rules{Fields: map[string]SelectorRule{"price": {Selector: "span["}}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
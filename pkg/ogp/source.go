@@ -0,0 +1,107 @@
+package ogp
+
+import "unicode/utf8"
+
+// Source identifies where a metadata value was extracted from.
+type Source string
+
+// Metadata sources.
+const (
+	SourceSelector      Source = "selector"
+	SourceOG            Source = "og"
+	SourceTwitter       Source = "twitter"
+	SourceJSONLD        Source = "jsonld"
+	SourceMicrodata     Source = "microdata"
+	SourceMeta          Source = "meta"
+	SourceDublinCore    Source = "dublin-core"
+	SourceHTMLTitle     Source = "html-title"
+	SourceTime          Source = "time"
+	SourceImg           Source = "img"
+	SourceIcon          Source = "icon"
+	SourceManifest      Source = "manifest"
+	SourceContent       Source = "content"
+	SourceOEmbed        Source = "oembed"
+	SourceAPI           Source = "api"
+	SourceLinkedContent Source = "linked-content"
+)
+
+// Result fields recorded in Result.Sources.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldImage       = "image"
+	FieldSiteName    = "site_name"
+	FieldPublished   = "published"
+	FieldIcon        = "icon"
+)
+
+// fieldWeights are the points each field adds to Result.Score; they sum to 100.
+var fieldWeights = map[string]int{
+	FieldTitle:       30,
+	FieldDescription: 25,
+	FieldImage:       30,
+	FieldSiteName:    5,
+	FieldPublished:   5,
+	FieldIcon:        5,
+}
+
+// setSource records the source of a result field; an empty source removes it.
+func (r *Result) setSource(field string, source Source) {
+	if source == "" {
+		delete(r.Sources, field)
+		return
+	}
+	if r.Sources == nil {
+		r.Sources = make(map[string]Source)
+	}
+	r.Sources[field] = source
+}
+
+// recordSources records source for every non-empty field without a source yet.
+func (r *Result) recordSources(source Source) {
+	for field, value := range r.fieldValues() {
+		if value != "" && r.Sources[field] == "" {
+			r.setSource(field, source)
+		}
+	}
+}
+
+func (r *Result) fieldValues() map[string]string {
+	return map[string]string{
+		FieldTitle:       r.Title,
+		FieldDescription: r.Description,
+		FieldImage:       r.Image,
+		FieldSiteName:    r.SiteName,
+		FieldPublished:   r.Published,
+		FieldIcon:        r.Icon,
+	}
+}
+
+// completenessScore rates from 0 to 100 how complete a preview is. Each
+// present field adds its weight, reduced for low-quality values: a
+// description repeating the title or shorter than 20 characters counts half,
+// and images taken from <img> tags or icons count two thirds and one third.
+func completenessScore(r *Result) int {
+	var score int
+	for field, value := range r.fieldValues() {
+		if value == "" {
+			continue
+		}
+		weight := fieldWeights[field]
+		switch field {
+		case FieldDescription:
+			if value == r.Title || utf8.RuneCountInString(value) < 20 {
+				weight /= 2
+			}
+		case FieldImage:
+			switch r.Sources[FieldImage] {
+			case SourceImg:
+				weight = weight * 2 / 3
+			case SourceIcon, SourceManifest:
+				weight /= 3
+			}
+		}
+		score += weight
+	}
+	return score
+}
@@ -0,0 +1,142 @@
+package ogp
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFetch_Sources(t *testing.T) {
+	tests := map[string]struct {
+		html string
+		want map[string]Source
+	}{
+		"opengraph": {
+			html: `<head>
+				<meta property="og:title" content="OG Title">
+				<meta property="og:description" content="OG Description">
+				<meta property="og:image" content="https://example.com/og.png">
+				<meta property="og:site_name" content="Example">
+				<title>HTML Title</title>
+			</head>`,
+			want: map[string]Source{
+				FieldTitle:       SourceOG,
+				FieldDescription: SourceOG,
+				FieldImage:       SourceOG,
+				FieldSiteName:    SourceOG,
+				FieldIcon:        SourceIcon,
+			},
+		},
+		"html fallbacks": {
+			html: `<head>
+				<title>HTML Title</title>
+				<meta name="description" content="Meta Description">
+				<meta name="twitter:image" content="/twitter.png">
+				<script type="application/ld+json">{"@type": "Article", "datePublished": "2024-03-01"}</script>
+			</head><body><img src="/first.png"></body>`,
+			want: map[string]Source{
+				FieldTitle:       SourceHTMLTitle,
+				FieldDescription: SourceMeta,
+				FieldImage:       SourceTwitter,
+				FieldPublished:   SourceJSONLD,
+				FieldIcon:        SourceIcon,
+			},
+		},
+		"img and icon": {
+			html: `<head><title>T</title><link rel="icon" href="/icon.png"></head><body><img src="/first.png"></body>`,
+			want: map[string]Source{
+				FieldTitle: SourceHTMLTitle,
+				FieldImage: SourceImg,
+				FieldIcon:  SourceIcon,
+			},
+		},
+		"icon is not an image": {
+			html: `<head><title>T</title><link rel="icon" href="/icon.png"></head>`,
+			want: map[string]Source{
+				FieldTitle: SourceHTMLTitle,
+				FieldIcon:  SourceIcon,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeHTTPClient{
+				handler: func(req *http.Request) ([]byte, int, error) {
+					return []byte(tc.html), 200, nil
+				},
+			}
+			result := NewFetcher(client).Fetch(t.Context(), "https://example.com/page")
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			for field, want := range tc.want {
+				if got := result.Sources[field]; got != want {
+					t.Errorf("got %s source %q, want %q", field, got, want)
+				}
+			}
+			if len(result.Sources) != len(tc.want) {
+				t.Errorf("got sources %v, want %v", result.Sources, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_Sources_ContentLead(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			return []byte(articlePage), 200, nil
+		},
+	}
+	result := NewFetcher(client, WithContentExtraction(true)).Fetch(t.Context(), "https://example.com/post")
+	if got := result.Sources[FieldDescription]; got != SourceContent {
+		t.Errorf("got description source %q, want %q", got, SourceContent)
+	}
+}
+
+func TestCompletenessScore(t *testing.T) {
+	full := func() *Result {
+		return &Result{
+			Title:       "A title",
+			Description: "A description long enough to count",
+			Image:       "https://example.com/og.png",
+			SiteName:    "Example",
+			Published:   "2024-03-01T00:00:00Z",
+			Icon:        "https://example.com/favicon.ico",
+			Sources:     map[string]Source{FieldImage: SourceOG},
+		}
+	}
+	tests := map[string]struct {
+		result *Result
+		want   int
+	}{
+		"empty": {result: &Result{}, want: 0},
+		"complete": {
+			result: full(),
+			want:   100,
+		},
+		"title only": {
+			result: &Result{Title: "A title"},
+			want:   30,
+		},
+		"description repeats title": {
+			result: func() *Result { r := full(); r.Description = r.Title; return r }(),
+			want:   87,
+		},
+		"image from img": {
+			result: func() *Result { r := full(); r.Sources[FieldImage] = SourceImg; return r }(),
+			want:   90,
+		},
+		"image from icon": {
+			result: func() *Result { r := full(); r.Sources[FieldImage] = SourceIcon; return r }(),
+			want:   80,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := completenessScore(tc.result); got != tc.want {
+				t.Errorf("completenessScore() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// tweetIDPattern matches the path of a tweet: /<user>/status/<id> or /i/web/status/<id>.
+var tweetIDPattern = regexp.MustCompile(`/status(?:es)?/(\d+)`)
+
+// tweetCardImageKeys are the card binding values holding an image, largest first.
+var tweetCardImageKeys = []string{
+	"photo_image_full_size_large", "summary_photo_image_large", "thumbnail_image_large",
+	"player_image_large", "photo_image_full_size", "summary_photo_image", "thumbnail_image", "player_image",
+}
+
+// MediaVariant is an encoding of a video or GIF.
+type MediaVariant struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Bitrate     int    `json:"bitrate,omitempty"`
+}
+
+type tweetImageValue struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type syndicationTweet struct {
+	Text              string `json:"text"`
+	CreatedAt         string `json:"created_at"`
+	FavoriteCount     int    `json:"favorite_count"`
+	ConversationCount int    `json:"conversation_count"`
+	User              struct {
+		Name       string `json:"name"`
+		ScreenName string `json:"screen_name"`
+	} `json:"user"`
+	MediaDetails []struct {
+		Type          string `json:"type"`
+		MediaURLHTTPS string `json:"media_url_https"`
+		ExtAltText    string `json:"ext_alt_text"`
+		OriginalInfo  struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"original_info"`
+		VideoInfo struct {
+			Variants []struct {
+				Bitrate     int    `json:"bitrate"`
+				ContentType string `json:"content_type"`
+				URL         string `json:"url"`
+			} `json:"variants"`
+		} `json:"video_info"`
+	} `json:"mediaDetails"`
+	Card *struct {
+		URL           string `json:"url"`
+		BindingValues map[string]struct {
+			StringValue string           `json:"string_value"`
+			ImageValue  *tweetImageValue `json:"image_value"`
+		} `json:"binding_values"`
+	} `json:"card"`
+}
+
+// tweetID returns the ID of a tweet URL.
+func tweetID(tweetURL string) (string, bool) {
+	parsed, err := url.Parse(tweetURL)
+	if err != nil {
+		return "", false
+	}
+	m := tweetIDPattern.FindStringSubmatch(parsed.Path)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// fetchTweetPost reads the author, text, counts, media and card of a tweet
+// from the syndication endpoint used by embedded tweets.
+func (f *Fetcher) fetchTweetPost(ctx context.Context, id string) (*Post, error) {
+	reqURL := fmt.Sprintf("%s?id=%s&token=%s", f.endpoint(EndpointTwitterSyndication), id, syndicationToken(id))
+	var tweet syndicationTweet
+	if err := f.fetchJSON(ctx, reqURL, &tweet, nil); err != nil {
+		return nil, err
+	}
+	if tweet.User.ScreenName == "" {
+		// unavailable tweets answer an empty object
+		return nil, fmt.Errorf("tweet %s not found", id)
+	}
+
+	post := &Post{
+		Author:    tweet.User.Name,
+		Handle:    "@" + tweet.User.ScreenName,
+		AuthorURL: "https://x.com/" + tweet.User.ScreenName,
+		Text:      tweet.Text,
+		Created:   normalizeTime(tweet.CreatedAt),
+		Replies:   tweet.ConversationCount,
+		Likes:     tweet.FavoriteCount,
+	}
+	for _, m := range tweet.MediaDetails {
+		media := Media{
+			Type:   MediaImage,
+			URL:    m.MediaURLHTTPS,
+			Width:  m.OriginalInfo.Width,
+			Height: m.OriginalInfo.Height,
+			Alt:    m.ExtAltText,
+		}
+		if m.Type == "video" || m.Type == "animated_gif" {
+			media.Type, media.PreviewURL = MediaVideo, m.MediaURLHTTPS
+			if m.Type == "animated_gif" {
+				media.Type = MediaGIF
+			}
+			for _, v := range m.VideoInfo.Variants {
+				media.Variants = append(media.Variants, MediaVariant{URL: v.URL, ContentType: v.ContentType, Bitrate: v.Bitrate})
+			}
+			// highest bitrate first; streaming playlists have none and come last
+			slices.SortStableFunc(media.Variants, func(a, b MediaVariant) int { return b.Bitrate - a.Bitrate })
+			media.URL = ""
+			if len(media.Variants) > 0 {
+				media.URL = media.Variants[0].URL
+			}
+		}
+		post.Media = append(post.Media, media)
+	}
+	if card := tweet.Card; card != nil {
+		linked := &Result{URL: card.URL, Title: card.BindingValues["title"].StringValue, Description: card.BindingValues["description"].StringValue}
+		for _, key := range tweetCardImageKeys {
+			if img := card.BindingValues[key].ImageValue; img != nil && img.URL != "" {
+				linked.Image, linked.ImageWidth, linked.ImageHeight = img.URL, img.Width, img.Height
+				break
+			}
+		}
+		linked.recordSources(SourceAPI)
+		post.Linked = linked
+	}
+	return post, nil
+}
+
+// syndicationToken computes the token the embedded tweet widget sends with
+// a tweet ID: (id / 1e15 * π) in base 36, without zeros and the point.
+func syndicationToken(id string) string {
+	n, err := strconv.ParseFloat(id, 64)
+	if err != nil {
+		return ""
+	}
+	return strings.NewReplacer("0", "", ".", "").Replace(formatFloatRadix(n/1e15*math.Pi, 36))
+}
+
+// formatFloatRadix formats a non-negative number like JavaScript's
+// Number.prototype.toString(radix): the shortest digits that read back to
+// the same number.
+func formatFloatRadix(value float64, radix int) string {
+	const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+	integer := math.Floor(value)
+	fraction := value - integer
+	// the digits are precise up to half the distance to the next number
+	delta := max(0.5*(math.Nextafter(value, math.Inf(1))-value), math.SmallestNonzeroFloat64)
+
+	var frac []byte
+	if fraction >= delta {
+		for {
+			fraction *= float64(radix)
+			delta *= float64(radix)
+			digit := int(fraction)
+			frac = append(frac, digits[digit])
+			fraction -= float64(digit)
+			if fraction > 0.5 || (fraction == 0.5 && digit&1 == 1) {
+				if fraction+delta > 1 {
+					// round up, propagating the carry
+					for {
+						if len(frac) == 0 {
+							integer++
+							break
+						}
+						last := strings.IndexByte(digits, frac[len(frac)-1])
+						frac = frac[:len(frac)-1]
+						if last+1 < radix {
+							frac = append(frac, digits[last+1])
+							break
+						}
+					}
+					break
+				}
+			}
+			if fraction < delta {
+				break
+			}
+		}
+	}
+
+	s := strconv.FormatInt(int64(integer), radix)
+	if len(frac) > 0 {
+		s += "." + string(frac)
+	}
+	return s
+}
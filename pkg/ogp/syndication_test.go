@@ -0,0 +1,134 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSyndicationToken(t *testing.T) {
+	// expected values computed with the widget's JavaScript expression
+	tests := map[string]struct {
+		id   string
+		want string
+	}{
+		"recent":  {id: "1949232114118820349", want: "4q3oyi4qif2"},
+		"older":   {id: "1683920951807971329", want: "42y6zv7ufp"},
+		"tiny id": {id: "20", want: "6dq1a2xwd93"},
+		"invalid": {id: "abc", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := syndicationToken(tc.id); got != tc.want {
+				t.Errorf("syndicationToken(%q) = %q, want %q", tc.id, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_TweetMedia(t *testing.T) {
+	oembed := `{"author_name": "Gopher", "html": "<blockquote class=\"twitter-tweet\"><p>Look</p></blockquote>"}`
+	tweets := map[string]string{
+		"1": `{"text": "Look", "created_at": "2024-03-01T12:00:00.000Z", "favorite_count": 10, "conversation_count": 2,
+			"user": {"name": "Gopher", "screen_name": "gopher"},
+			"mediaDetails": [
+				{"type": "photo", "media_url_https": "https://pbs.example/a.jpg", "ext_alt_text": "A gopher",
+					"original_info": {"width": 1200, "height": 800}},
+				{"type": "video", "media_url_https": "https://pbs.example/poster.jpg", "original_info": {"width": 1280, "height": 720},
+					"video_info": {"variants": [
+						{"content_type": "application/x-mpegURL", "url": "https://video.example/v.m3u8"},
+						{"bitrate": 832000, "content_type": "video/mp4", "url": "https://video.example/v-832.mp4"},
+						{"bitrate": 2176000, "content_type": "video/mp4", "url": "https://video.example/v-2176.mp4"}]}},
+				{"type": "animated_gif", "media_url_https": "https://pbs.example/gif.jpg", "original_info": {"width": 480, "height": 270},
+					"video_info": {"variants": [{"bitrate": 0, "content_type": "video/mp4", "url": "https://video.example/g.mp4"}]}}]}`,
+		"2": `{"text": "Read https://t.co/x", "created_at": "2024-03-02T12:00:00.000Z",
+			"user": {"name": "Gopher", "screen_name": "gopher"},
+			"card": {"url": "https://t.co/x", "binding_values": {
+				"title": {"type": "STRING", "string_value": "Card Title"},
+				"thumbnail_image_large": {"type": "IMAGE", "image_value": {"url": "https://pbs.example/thumb.jpg", "width": 600, "height": 314}},
+				"summary_photo_image_large": {"type": "IMAGE", "image_value": {"url": "https://pbs.example/card.jpg", "width": 1200, "height": 628}}}}}`,
+		"3": `{}`,
+	}
+	var gotTokens []string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch {
+			case strings.Contains(req.URL.String(), "publish.twitter.com/oembed"):
+				return []byte(oembed), 200, nil
+			case req.URL.Host == "stub":
+				gotTokens = append(gotTokens, req.URL.Query().Get("token"))
+				if body, ok := tweets[req.URL.Query().Get("id")]; ok {
+					return []byte(body), 200, nil
+				}
+			}
+			return []byte("Not Found"), 404, nil
+		},
+	}
+	fetcher := NewFetcher(client, WithEndpoint(EndpointTwitterSyndication, "http://stub/tweet-result"))
+
+	tests := map[string]struct {
+		url        string
+		wantImage  string
+		wantMedia  []Media
+		wantLinked bool
+	}{
+		"photo, video and gif": {
+			url:       "https://x.com/gopher/status/1",
+			wantImage: "https://pbs.example/a.jpg",
+			wantMedia: []Media{
+				{Type: MediaImage, URL: "https://pbs.example/a.jpg", Width: 1200, Height: 800, Alt: "A gopher"},
+				{Type: MediaVideo, URL: "https://video.example/v-2176.mp4", PreviewURL: "https://pbs.example/poster.jpg", Width: 1280, Height: 720,
+					Variants: []MediaVariant{
+						{URL: "https://video.example/v-2176.mp4", ContentType: "video/mp4", Bitrate: 2176000},
+						{URL: "https://video.example/v-832.mp4", ContentType: "video/mp4", Bitrate: 832000},
+						{URL: "https://video.example/v.m3u8", ContentType: "application/x-mpegURL"}}},
+				{Type: MediaGIF, URL: "https://video.example/g.mp4", PreviewURL: "https://pbs.example/gif.jpg", Width: 480, Height: 270,
+					Variants: []MediaVariant{{URL: "https://video.example/g.mp4", ContentType: "video/mp4"}}},
+			},
+		},
+		"card": {
+			url:        "https://twitter.com/gopher/status/2",
+			wantImage:  "https://pbs.example/card.jpg",
+			wantLinked: true,
+		},
+		"unavailable": {
+			url: "https://x.com/gopher/status/3",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := fetcher.Fetch(t.Context(), tc.url)
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			if result.Image != tc.wantImage {
+				t.Errorf("got image %q, want %q", result.Image, tc.wantImage)
+			}
+			if tc.wantImage != "" && result.Sources[FieldImage] != SourceAPI {
+				t.Errorf("got image source %q, want %q", result.Sources[FieldImage], SourceAPI)
+			}
+			if tc.wantImage == "" {
+				if result.Post != nil {
+					t.Errorf("got post %+v, want nil", result.Post)
+				}
+				return
+			}
+			if result.Post == nil {
+				t.Fatal("got nil post")
+			}
+			if !reflect.DeepEqual(result.Post.Media, tc.wantMedia) {
+				t.Errorf("got media %+v, want %+v", result.Post.Media, tc.wantMedia)
+			}
+			if linked := result.Post.Linked; (linked != nil) != tc.wantLinked || (linked != nil && linked.Title != "Card Title") {
+				t.Errorf("got linked %+v", linked)
+			}
+		})
+	}
+
+	if len(gotTokens) != 3 || gotTokens[0] == "" {
+		t.Errorf("got tokens %q", gotTokens)
+	}
+}
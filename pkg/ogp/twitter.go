@@ -1,6 +1,7 @@
 package ogp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,7 +14,7 @@ import (
 	"golang.org/x/net/html"
 )
 
-const oEmbedAPIURL = "https://publish.twitter.com/oembed"
+const twitterSiteName = "X"
 
 type oEmbedResponse struct {
 	URL        string `json:"url"`
@@ -41,46 +42,76 @@ func IsTwitterURL(targetURL string) bool {
 	return slices.Contains(hosts, host)
 }
 
-func (f *Fetcher) fetchTwitter(tweetURL string) *Result {
-	oembed, err := f.fetchOEmbed(tweetURL)
+func (f *Fetcher) fetchTwitter(ctx context.Context, tweetURL string) *Result {
+	oembed, err := f.fetchOEmbed(ctx, tweetURL)
 	if err != nil {
 		log.Warnf("oEmbed API failed for %s: %v, falling back to general OGP", tweetURL, err)
-		return f.fetchGeneral(tweetURL)
+		return f.fetchGeneral(ctx, tweetURL)
 	}
 
-	title := fmt.Sprintf("@%s on X", oembed.AuthorName)
-	description := extractTextFromOEmbedHTML(oembed.HTML)
-	image := ""
+	result := &Result{
+		URL:         tweetURL,
+		Title:       fmt.Sprintf("@%s on X", oembed.AuthorName),
+		Description: extractTextFromOEmbedHTML(oembed.HTML),
+		SiteName:    twitterSiteName,
+	}
+	description := result.Description
+	f.applyTweetPost(ctx, result, tweetURL)
 
 	linkedURLs := extractURLs(description)
 	for _, u := range linkedURLs {
 		if IsTwitterURL(u) {
 			continue
 		}
-		linked := f.fetchLinkedContent(u)
+		linked := f.fetchLinkedContent(ctx, u)
 		if linked == nil {
 			continue
 		}
 		if isTcoOnly(description) && linked.Title != "" {
-			description = linked.Title
+			result.Description = linked.Title
+			result.setSource(FieldDescription, SourceLinkedContent)
 		}
-		if image == "" && linked.Image != "" {
-			image = linked.Image
+		if linked.Image != "" && result.Image == "" {
+			result.Image = linked.Image
+			result.setSource(FieldImage, SourceLinkedContent)
 		}
 		break
 	}
 
-	return &Result{
-		URL:         tweetURL,
-		Title:       title,
-		Description: description,
-		Image:       image,
+	result.recordSources(SourceOEmbed)
+	return result
+}
+
+// applyTweetPost adds the media, card and details of a tweet read from the
+// syndication endpoint. The first photo, video poster or card image becomes
+// the preview image.
+func (f *Fetcher) applyTweetPost(ctx context.Context, result *Result, tweetURL string) {
+	id, ok := tweetID(tweetURL)
+	if !ok {
+		return
+	}
+	post, err := f.fetchTweetPost(ctx, id)
+	if err != nil {
+		log.Debugf("tweet syndication failed for %s: %v", tweetURL, err)
+		return
+	}
+	result.Post = post
+	result.Image, result.ImageWidth, result.ImageHeight = previewImage(post.Media)
+	if result.Image == "" && post.Linked != nil {
+		result.Image, result.ImageWidth, result.ImageHeight = post.Linked.Image, post.Linked.ImageWidth, post.Linked.ImageHeight
+	}
+	if result.Image != "" {
+		result.setSource(FieldImage, SourceAPI)
+	}
+	if post.Created != "" {
+		result.Published = post.Created
+		result.setSource(FieldPublished, SourceAPI)
 	}
 }
 
-func (f *Fetcher) fetchOEmbed(tweetURL string) (*oEmbedResponse, error) {
-	reqURL := fmt.Sprintf("%s?url=%s&omit_script=true", oEmbedAPIURL, url.QueryEscape(tweetURL))
-	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+func (f *Fetcher) fetchOEmbed(ctx context.Context, tweetURL string) (*oEmbedResponse, error) {
+	reqURL := fmt.Sprintf("%s?url=%s&omit_script=true", f.endpoint(EndpointTwitterOEmbed), url.QueryEscape(tweetURL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create oEmbed request: %w", err)
 	}
@@ -101,8 +132,8 @@ func (f *Fetcher) fetchOEmbed(tweetURL string) (*oEmbedResponse, error) {
 	return &oembed, nil
 }
 
-func (f *Fetcher) fetchLinkedContent(linkedURL string) *Result {
-	linked := f.fetchGeneral(linkedURL)
+func (f *Fetcher) fetchLinkedContent(ctx context.Context, linkedURL string) *Result {
+	linked := f.fetchGeneral(ctx, linkedURL)
 	if linked.Err != nil {
 		return nil
 	}
@@ -113,17 +144,22 @@ func (f *Fetcher) fetchLinkedContent(linkedURL string) *Result {
 
 	// Title is empty: the URL may have redirected to a Twitter page with no OGP
 	// Try oEmbed for the original linked URL (e.g., t.co → x.com/i/article)
-	redirectOembed, err := f.fetchOEmbed(linkedURL)
+	redirectOembed, err := f.fetchOEmbed(ctx, linkedURL)
 	if err != nil {
 		return nil
 	}
 
-	return &Result{
+	result := &Result{
 		URL:         linkedURL,
 		Title:       redirectOembed.AuthorName,
 		Description: extractTextFromOEmbedHTML(redirectOembed.HTML),
 		Image:       linked.Image,
 	}
+	if result.Image != "" {
+		result.setSource(FieldImage, linked.Sources[FieldImage])
+	}
+	result.recordSources(SourceOEmbed)
+	return result
 }
 
 func extractTextFromOEmbedHTML(htmlStr string) string {
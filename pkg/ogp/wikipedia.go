@@ -0,0 +1,121 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// wikiHostPattern matches the desktop and mobile hosts of language editions
+// of Wikipedia and its sister projects, e.g. ja.wikipedia.org or en.m.wiktionary.org.
+var wikiHostPattern = regexp.MustCompile(`^([a-z][a-z0-9-]*)(?:\.m)?\.(wikipedia|wiktionary|wikivoyage|wikibooks|wikiquote|wikinews|wikisource)\.org$`)
+
+// wikiSiteNames are the site names of the supported projects.
+var wikiSiteNames = map[string]string{
+	"wikipedia":  "Wikipedia",
+	"wiktionary": "Wiktionary",
+	"wikivoyage": "Wikivoyage",
+	"wikibooks":  "Wikibooks",
+	"wikiquote":  "Wikiquote",
+	"wikinews":   "Wikinews",
+	"wikisource": "Wikisource",
+}
+
+type wikiSummaryResponse struct {
+	Title  string `json:"title"`
+	Titles struct {
+		Canonical  string `json:"canonical"`
+		Normalized string `json:"normalized"`
+	} `json:"titles"`
+	Lang          string    `json:"lang"`
+	Extract       string    `json:"extract"`
+	Thumbnail     *wikiLink `json:"thumbnail"`
+	OriginalImage *wikiLink `json:"originalimage"`
+}
+
+type wikiLink struct {
+	Source string `json:"source"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// wikiRef is a wiki page URL broken down into its desktop host, project and title.
+type wikiRef struct {
+	host    string
+	lang    string
+	project string
+	title   string
+}
+
+// IsWikipediaURL checks if the URL is a page of a Wikipedia or sister
+// project language edition, desktop or mobile.
+func IsWikipediaURL(targetURL string) bool {
+	_, ok := parseWikiURL(targetURL)
+	return ok
+}
+
+// parseWikiURL parses /wiki/<title> and /w/index.php?title=<title> URLs.
+func parseWikiURL(targetURL string) (wikiRef, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return wikiRef{}, false
+	}
+	m := wikiHostPattern.FindStringSubmatch(strings.ToLower(parsed.Hostname()))
+	if m == nil || m[1] == "www" {
+		return wikiRef{}, false
+	}
+	var title string
+	switch {
+	case strings.HasPrefix(parsed.Path, "/wiki/"):
+		// Path is already percent-decoded
+		title = strings.TrimPrefix(parsed.Path, "/wiki/")
+	case parsed.Path == "/w/index.php":
+		title = parsed.Query().Get("title")
+	}
+	if title == "" {
+		return wikiRef{}, false
+	}
+	return wikiRef{
+		host:    m[1] + "." + m[2] + ".org",
+		lang:    m[1],
+		project: m[2],
+		title:   strings.ReplaceAll(title, " ", "_"),
+	}, true
+}
+
+func (f *Fetcher) fetchWikipedia(ctx context.Context, targetURL string) *Result {
+	ref, _ := parseWikiURL(targetURL)
+
+	// the API redirects to the summary of the target page of redirect titles
+	base := strings.ReplaceAll(f.endpoint(EndpointWikipediaAPI), "{host}", ref.host)
+	var summary wikiSummaryResponse
+	if err := f.fetchJSON(ctx, base+"/page/summary/"+url.PathEscape(ref.title), &summary, nil); err != nil {
+		log.Warnf("Wikipedia API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+
+	result := &Result{
+		URL:         targetURL,
+		Title:       summary.Titles.Normalized,
+		Description: summary.Extract,
+		SiteName:    wikiSiteNames[ref.project],
+		Lang:        summary.Lang,
+	}
+	if result.Title == "" {
+		result.Title = summary.Title
+	}
+	if result.Lang == "" {
+		result.Lang = ref.lang
+	}
+	for _, img := range []*wikiLink{summary.OriginalImage, summary.Thumbnail} {
+		if img != nil && img.Source != "" {
+			result.Image, result.ImageWidth, result.ImageHeight = img.Source, img.Width, img.Height
+			break
+		}
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
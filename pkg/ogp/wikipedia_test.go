@@ -0,0 +1,92 @@
+package ogp
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseWikiURL(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		want   wikiRef
+		wantOK bool
+	}{
+		"encoded title": {
+			url:    "https://ja.wikipedia.org/wiki/Go_(%E3%83%97%E3%83%AD%E3%82%B0%E3%83%A9%E3%83%9F%E3%83%B3%E3%82%B0%E8%A8%80%E8%AA%9E)",
+			want:   wikiRef{host: "ja.wikipedia.org", lang: "ja", project: "wikipedia", title: "Go_(プログラミング言語)"},
+			wantOK: true,
+		},
+		"mobile": {
+			url:    "https://en.m.wikipedia.org/wiki/Gopher#Etymology",
+			want:   wikiRef{host: "en.wikipedia.org", lang: "en", project: "wikipedia", title: "Gopher"},
+			wantOK: true,
+		},
+		"index.php": {
+			url:    "https://de.wiktionary.org/w/index.php?title=Haus+Boot",
+			want:   wikiRef{host: "de.wiktionary.org", lang: "de", project: "wiktionary", title: "Haus_Boot"},
+			wantOK: true,
+		},
+		"portal":     {url: "https://www.wikipedia.org/wiki/Gopher"},
+		"main page":  {url: "https://en.wikipedia.org/"},
+		"commons":    {url: "https://commons.wikimedia.org/wiki/File:Gopher.png"},
+		"other site": {url: "https://en.example.org/wiki/Gopher"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseWikiURL(tc.url)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("parseWikiURL(%q) = %+v, %v, want %+v, %v", tc.url, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestFetch_Wikipedia(t *testing.T) {
+	var gotPath string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			gotPath = req.URL.Host + req.URL.Path
+			return []byte(`{"title": "Go (プログラミング言語)", "titles": {"canonical": "Go_(プログラミング言語)",
+				"normalized": "Go (プログラミング言語)"}, "lang": "ja", "extract": "Goはプログラミング言語の一つ。",
+				"thumbnail": {"source": "https://upload.example/320px-Go.png", "width": 320, "height": 120},
+				"originalimage": {"source": "https://upload.example/Go.png", "width": 1200, "height": 450}}`), 200, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://ja.m.wikipedia.org/wiki/Go_(%E3%83%97%E3%83%AD%E3%82%B0%E3%83%A9%E3%83%9F%E3%83%B3%E3%82%B0%E8%A8%80%E8%AA%9E)")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if want := "ja.wikipedia.org/api/rest_v1/page/summary/Go_(プログラミング言語)"; gotPath != want {
+		t.Errorf("got request %q, want %q", gotPath, want)
+	}
+	if result.Title != "Go (プログラミング言語)" || result.Description != "Goはプログラミング言語の一つ。" {
+		t.Errorf("got title %q and description %q", result.Title, result.Description)
+	}
+	if result.Image != "https://upload.example/Go.png" || result.ImageWidth != 1200 || result.ImageHeight != 450 {
+		t.Errorf("got image %q (%dx%d)", result.Image, result.ImageWidth, result.ImageHeight)
+	}
+	if result.SiteName != "Wikipedia" || result.Lang != "ja" {
+		t.Errorf("got site name %q and lang %q", result.SiteName, result.Lang)
+	}
+	if result.Sources[FieldDescription] != SourceAPI {
+		t.Errorf("got description source %q, want %q", result.Sources[FieldDescription], SourceAPI)
+	}
+}
+
+func TestFetch_WikipediaFallback(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if req.URL.Host == "stub" {
+				return []byte(`{"type": "not_found"}`), 404, nil
+			}
+			return []byte(`<html lang="en"><head><title>Special page</title></head></html>`), 200, nil
+		},
+	}
+	result := NewFetcher(client, WithEndpoint(EndpointWikipediaAPI, "http://stub")).Fetch(t.Context(), "https://en.wikipedia.org/wiki/Special:Random")
+
+	if result.Title != "Special page" {
+		t.Errorf("got title %q, want %q", result.Title, "Special page")
+	}
+}
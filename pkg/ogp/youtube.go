@@ -0,0 +1,213 @@
+package ogp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+)
+
+const (
+	youtubeSiteName = "YouTube"
+	// youtubeWatchPageBytes caps the watch page read; the metadata comes
+	// well before the end of the page.
+	youtubeWatchPageBytes = 1 << 20
+)
+
+var (
+	youtubeHosts = []string{
+		"youtube.com", "www.youtube.com", "m.youtube.com", "music.youtube.com",
+		"youtube-nocookie.com", "www.youtube-nocookie.com",
+	}
+	youtubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	// youtubeThumbnails are tried from the highest resolution down. Their
+	// sizes are fixed, so only their existence is checked.
+	youtubeThumbnails = []struct {
+		name          string
+		width, height int
+	}{
+		{"maxresdefault.jpg", 1280, 720},
+		{"sddefault.jpg", 640, 480},
+		{"hqdefault.jpg", 480, 360},
+	}
+
+	youtubeDurationPattern    = regexp.MustCompile(`itemprop="duration"\s+content="([^"]+)"`)
+	youtubeLengthPattern      = regexp.MustCompile(`"lengthSeconds":"(\d+)"`)
+	youtubePublishedPattern   = regexp.MustCompile(`itemprop="(?:datePublished|uploadDate)"\s+content="([^"]+)"`)
+	youtubeDescriptionPattern = regexp.MustCompile(`<meta\s+name="description"\s+content="([^"]*)"`)
+	iso8601DurationPattern    = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+)
+
+// Video holds metadata of a video.
+type Video struct {
+	ID         string `json:"id"`
+	Channel    string `json:"channel,omitempty"`
+	ChannelURL string `json:"channel_url,omitempty"`
+	// Duration is the length in seconds, 0 if unknown.
+	Duration int    `json:"duration,omitempty"`
+	EmbedURL string `json:"embed_url,omitempty"`
+}
+
+type youtubeOEmbedResponse struct {
+	Title           string `json:"title"`
+	AuthorName      string `json:"author_name"`
+	AuthorURL       string `json:"author_url"`
+	ProviderName    string `json:"provider_name"`
+	ThumbnailURL    string `json:"thumbnail_url"`
+	ThumbnailWidth  int    `json:"thumbnail_width"`
+	ThumbnailHeight int    `json:"thumbnail_height"`
+}
+
+// IsYouTubeURL checks if the URL is a YouTube video URL: watch, youtu.be,
+// shorts, live or embed.
+func IsYouTubeURL(targetURL string) bool {
+	_, ok := youtubeVideoID(targetURL)
+	return ok
+}
+
+// youtubeVideoID returns the video ID of a YouTube video URL.
+func youtubeVideoID(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil {
+		return "", false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+
+	var id string
+	switch {
+	case host == "youtu.be":
+		id = segments[0]
+	case slices.Contains(youtubeHosts, host):
+		switch segments[0] {
+		case "watch":
+			id = parsed.Query().Get("v")
+		case "shorts", "live", "embed", "v":
+			if len(segments) > 1 {
+				id = segments[1]
+			}
+		}
+	}
+	return id, youtubeIDPattern.MatchString(id)
+}
+
+func (f *Fetcher) fetchYouTube(ctx context.Context, targetURL string) *Result {
+	id, _ := youtubeVideoID(targetURL)
+	watchURL := "https://www.youtube.com/watch?v=" + id
+
+	var oembed youtubeOEmbedResponse
+	reqURL := fmt.Sprintf("%s?url=%s&format=json", f.endpoint(EndpointYouTubeOEmbed), url.QueryEscape(watchURL))
+	if err := f.fetchJSON(ctx, reqURL, &oembed, nil); err != nil {
+		log.Warnf("YouTube oEmbed failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+
+	result := &Result{
+		URL:      targetURL,
+		Title:    oembed.Title,
+		SiteName: oembed.ProviderName,
+		Video: &Video{
+			ID:         id,
+			Channel:    oembed.AuthorName,
+			ChannelURL: oembed.AuthorURL,
+			EmbedURL:   "https://www.youtube.com/embed/" + id,
+		},
+	}
+	if result.SiteName == "" {
+		result.SiteName = youtubeSiteName
+	}
+	f.applyYouTubeThumbnail(ctx, result, id, &oembed)
+	f.applyYouTubeWatchPage(ctx, result, id)
+	result.recordSources(SourceOEmbed)
+	return result
+}
+
+// applyYouTubeThumbnail sets the highest-resolution thumbnail that exists,
+// falling back to the oEmbed thumbnail.
+func (f *Fetcher) applyYouTubeThumbnail(ctx context.Context, result *Result, id string, oembed *youtubeOEmbedResponse) {
+	for _, thumb := range youtubeThumbnails {
+		thumbURL := fmt.Sprintf("%s/%s/%s", f.endpoint(EndpointYouTubeThumbnail), id, thumb.name)
+		if err := f.checkExists(ctx, thumbURL); err != nil {
+			log.Debugf("YouTube thumbnail %s unavailable: %v", thumbURL, err)
+			continue
+		}
+		result.Image, result.ImageWidth, result.ImageHeight, result.ImageType = thumbURL, thumb.width, thumb.height, "image/jpeg"
+		return
+	}
+	result.Image, result.ImageWidth, result.ImageHeight = oembed.ThumbnailURL, oembed.ThumbnailWidth, oembed.ThumbnailHeight
+}
+
+// checkExists sends a HEAD request for targetURL.
+func (f *Fetcher) checkExists(ctx context.Context, targetURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, targetURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+	_, statusCode, err := f.client.Request(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch: %w", err)
+	}
+	if statusCode >= http.StatusBadRequest {
+		return fmt.Errorf("HTTP %d", statusCode)
+	}
+	return nil
+}
+
+// applyYouTubeWatchPage adds the duration, description and publish date
+// found in the watch page. Consent and error pages carry none of them.
+func (f *Fetcher) applyYouTubeWatchPage(ctx context.Context, result *Result, id string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.endpoint(EndpointYouTubeWatch)+"?v="+url.QueryEscape(id), nil)
+	if err != nil {
+		log.Debugf("failed to create YouTube watch page request: %v", err)
+		return
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+	body, statusCode, err := f.requestPrefix(req, youtubeWatchPageBytes)
+	if err != nil || statusCode != http.StatusOK {
+		log.Debugf("failed to fetch YouTube watch page of %s: %d %v", id, statusCode, err)
+		return
+	}
+
+	if m := youtubeDurationPattern.FindSubmatch(body); m != nil {
+		result.Video.Duration = parseISODuration(string(m[1]))
+	} else if m := youtubeLengthPattern.FindSubmatch(body); m != nil {
+		result.Video.Duration, _ = strconv.Atoi(string(m[1]))
+	}
+	if result.Video.Duration == 0 {
+		// not a watch page: its description would be generic
+		return
+	}
+	if m := youtubeDescriptionPattern.FindSubmatch(body); m != nil {
+		result.Description = html.UnescapeString(string(m[1]))
+		result.setSource(FieldDescription, SourceMeta)
+	}
+	if m := youtubePublishedPattern.FindSubmatch(body); m != nil {
+		if published := normalizeTime(string(m[1])); published != "" {
+			result.Published = published
+			result.setSource(FieldPublished, SourceMeta)
+		}
+	}
+}
+
+// parseISODuration returns the seconds of an ISO 8601 duration such as
+// PT1H2M3S, or 0 when it is not one.
+func parseISODuration(s string) int {
+	m := iso8601DurationPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	var seconds int
+	for i, unit := range []int{24 * 3600, 3600, 60, 1} {
+		n, _ := strconv.Atoi(m[i+1])
+		seconds += n * unit
+	}
+	return seconds
+}
@@ -0,0 +1,142 @@
+package ogp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestYouTubeVideoID(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		wantID string
+		wantOK bool
+	}{
+		"watch":        {url: "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42s", wantID: "dQw4w9WgXcQ", wantOK: true},
+		"mobile watch": {url: "https://m.youtube.com/watch?v=dQw4w9WgXcQ", wantID: "dQw4w9WgXcQ", wantOK: true},
+		"short link":   {url: "https://youtu.be/dQw4w9WgXcQ?si=abc", wantID: "dQw4w9WgXcQ", wantOK: true},
+		"shorts":       {url: "https://youtube.com/shorts/abcdefghijk", wantID: "abcdefghijk", wantOK: true},
+		"live":         {url: "https://www.youtube.com/live/abc-def_123", wantID: "abc-def_123", wantOK: true},
+		"embed":        {url: "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", wantID: "dQw4w9WgXcQ", wantOK: true},
+		"channel":      {url: "https://www.youtube.com/@channel", wantOK: false},
+		"invalid id":   {url: "https://www.youtube.com/watch?v=short", wantID: "short", wantOK: false},
+		"other host":   {url: "https://example.com/watch?v=dQw4w9WgXcQ", wantOK: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, ok := youtubeVideoID(tc.url)
+			if id != tc.wantID || ok != tc.wantOK {
+				t.Errorf("youtubeVideoID(%q) = %q, %v, want %q, %v", tc.url, id, ok, tc.wantID, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  int
+	}{
+		"minutes and seconds": {value: "PT4M13S", want: 253},
+		"hours":               {value: "PT1H0M5S", want: 3605},
+		"days":                {value: "P1DT1S", want: 86401},
+		"invalid":             {value: "4:13", want: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := parseISODuration(tc.value); got != tc.want {
+				t.Errorf("parseISODuration(%q) = %d, want %d", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_YouTube(t *testing.T) {
+	var oembedURL string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			if strings.HasPrefix(req.URL.Path, "/vi/") && req.Method != http.MethodHead {
+				t.Errorf("thumbnail %s requested with %s, want HEAD", req.URL, req.Method)
+			}
+			switch u := req.URL.String(); {
+			case strings.HasPrefix(u, "http://stub/oembed"):
+				oembedURL = u
+				return []byte(`{"title": "Video Title", "author_name": "Channel", "author_url": "https://www.youtube.com/@channel",
+					"provider_name": "YouTube", "thumbnail_url": "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+					"thumbnail_width": 480, "thumbnail_height": 360}`), 200, nil
+			case u == "http://stub/vi/dQw4w9WgXcQ/sddefault.jpg":
+				return nil, 200, nil
+			case u == "http://stub/watch?v=dQw4w9WgXcQ":
+				return []byte(`<html><head><meta name="description" content="A &amp; B">
+					<meta itemprop="duration" content="PT3M33S">
+					<meta itemprop="datePublished" content="2009-10-24T23:57:33-07:00"></head></html>`), 200, nil
+			}
+			return nil, 404, nil
+		},
+	}
+	fetcher := NewFetcher(client,
+		WithEndpoint(EndpointYouTubeOEmbed, "http://stub/oembed"),
+		WithEndpoint(EndpointYouTubeWatch, "http://stub/watch"),
+		WithEndpoint(EndpointYouTubeThumbnail, "http://stub/vi/"),
+	)
+	result := fetcher.Fetch(t.Context(), "https://youtu.be/dQw4w9WgXcQ")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if !strings.Contains(oembedURL, "url=https%3A%2F%2Fwww.youtube.com%2Fwatch%3Fv%3DdQw4w9WgXcQ") {
+		t.Errorf("oEmbed requested with %q, want the canonical watch URL", oembedURL)
+	}
+	if result.Title != "Video Title" || result.SiteName != "YouTube" {
+		t.Errorf("got title %q and site name %q", result.Title, result.SiteName)
+	}
+	if result.Image != "http://stub/vi/dQw4w9WgXcQ/sddefault.jpg" || result.ImageWidth != 640 || result.ImageHeight != 480 {
+		t.Errorf("got image %q (%dx%d), want the sddefault thumbnail", result.Image, result.ImageWidth, result.ImageHeight)
+	}
+	if result.Description != "A & B" {
+		t.Errorf("got description %q, want %q", result.Description, "A & B")
+	}
+	if result.Published != "2009-10-24T23:57:33-07:00" {
+		t.Errorf("got published %q, want %q", result.Published, "2009-10-24T23:57:33-07:00")
+	}
+	want := Video{
+		ID:         "dQw4w9WgXcQ",
+		Channel:    "Channel",
+		ChannelURL: "https://www.youtube.com/@channel",
+		Duration:   213,
+		EmbedURL:   "https://www.youtube.com/embed/dQw4w9WgXcQ",
+	}
+	if result.Video == nil || *result.Video != want {
+		t.Errorf("got video %+v, want %+v", result.Video, want)
+	}
+	if result.Sources[FieldTitle] != SourceOEmbed {
+		t.Errorf("got title source %q, want %q", result.Sources[FieldTitle], SourceOEmbed)
+	}
+}
+
+func TestFetch_YouTube_ConsentPage(t *testing.T) {
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch u := req.URL.String(); {
+			case strings.HasPrefix(u, "https://www.youtube.com/oembed"):
+				return []byte(`{"title": "Video Title", "thumbnail_url": "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg"}`), 200, nil
+			case strings.HasPrefix(u, "https://www.youtube.com/watch"):
+				return []byte(`<html><head><meta name="description" content="Before you continue to YouTube"></head></html>`), 200, nil
+			}
+			return nil, 404, nil
+		},
+	}
+	result := NewFetcher(client).Fetch(t.Context(), "https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+
+	if result.Image != "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg" {
+		t.Errorf("got image %q, want the oEmbed thumbnail", result.Image)
+	}
+	if result.Description != "" {
+		t.Errorf("got description %q, want empty", result.Description)
+	}
+	if result.Video == nil || result.Video.Duration != 0 {
+		t.Errorf("got video %+v, want no duration", result.Video)
+	}
+}
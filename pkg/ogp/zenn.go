@@ -0,0 +1,88 @@
+package ogp
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const zennSiteName = "Zenn"
+
+// zennArticlePattern matches the path of Zenn articles: /<user>/articles/<slug>.
+var zennArticlePattern = regexp.MustCompile(`^/[^/]+/articles/([0-9a-z_-]+)/?$`)
+
+type zennArticleResponse struct {
+	Article struct {
+		Title         string `json:"title"`
+		BodyHTML      string `json:"body_html"`
+		PublishedAt   string `json:"published_at"`
+		BodyUpdatedAt string `json:"body_updated_at"`
+		LikedCount    int    `json:"liked_count"`
+		OGImageURL    string `json:"og_image_url"`
+		User          struct {
+			Username string `json:"username"`
+			Name     string `json:"name"`
+		} `json:"user"`
+		Topics []struct {
+			Name        string `json:"name"`
+			DisplayName string `json:"display_name"`
+		} `json:"topics"`
+	} `json:"article"`
+}
+
+// IsZennURL checks if the URL is a Zenn article.
+func IsZennURL(targetURL string) bool {
+	_, ok := zennArticleSlug(targetURL)
+	return ok
+}
+
+func zennArticleSlug(targetURL string) (string, bool) {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || parsed.Hostname() != "zenn.dev" {
+		return "", false
+	}
+	m := zennArticlePattern.FindStringSubmatch(parsed.Path)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+func (f *Fetcher) fetchZenn(ctx context.Context, targetURL string) *Result {
+	slug, _ := zennArticleSlug(targetURL)
+
+	var resp zennArticleResponse
+	if err := f.fetchJSON(ctx, f.endpoint(EndpointZennAPI)+"/articles/"+slug, &resp, nil); err != nil {
+		log.Warnf("Zenn API failed for %s: %v, falling back to general OGP", targetURL, err)
+		return f.fetchGeneral(ctx, targetURL)
+	}
+	article := resp.Article
+
+	author := article.User.Name
+	if author == "" {
+		author = article.User.Username
+	}
+	tags := make([]string, 0, len(article.Topics))
+	for _, topic := range article.Topics {
+		if topic.DisplayName != "" {
+			tags = append(tags, topic.DisplayName)
+		} else {
+			tags = append(tags, topic.Name)
+		}
+	}
+	result := &Result{
+		URL:         targetURL,
+		Title:       article.Title,
+		Description: excerpt(htmlToText(article.BodyHTML), maxExcerptLength),
+		Image:       article.OGImageURL,
+		SiteName:    zennSiteName,
+		Article:     apiArticle(article.PublishedAt, article.BodyUpdatedAt, []string{author}, tags, article.LikedCount),
+	}
+	if result.Article != nil {
+		result.Published = result.Article.Published
+	}
+	result.recordSources(SourceAPI)
+	return result
+}
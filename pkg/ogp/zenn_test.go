@@ -0,0 +1,66 @@
+package ogp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIsZennURL(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want bool
+	}{
+		"article":    {url: "https://zenn.dev/gopher/articles/go-generics-intro", want: true},
+		"book":       {url: "https://zenn.dev/gopher/books/go-book"},
+		"topic":      {url: "https://zenn.dev/topics/go"},
+		"other host": {url: "https://example.com/gopher/articles/go-generics-intro"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsZennURL(tc.url); got != tc.want {
+				t.Errorf("IsZennURL(%q) = %v, want %v", tc.url, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetch_Zenn(t *testing.T) {
+	var gotURL string
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			gotURL = req.URL.String()
+			return []byte(`{"article": {"title": "ジェネリクス入門", "body_html": "<p>型パラメータの話。</p>",
+				"published_at": "2024-03-01T12:00:00.000+09:00", "body_updated_at": null, "liked_count": 7,
+				"og_image_url": "https://zenn.example/og.png", "user": {"username": "gopher", "name": "Gopher"},
+				"topics": [{"name": "go", "display_name": "Go"}]}}`), 200, nil
+		},
+	}
+	result := NewFetcher(client, WithEndpoint(EndpointZennAPI, "http://stub")).Fetch(t.Context(), "https://zenn.dev/gopher/articles/go-generics-intro")
+
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if gotURL != "http://stub/articles/go-generics-intro" {
+		t.Errorf("got request %q", gotURL)
+	}
+	if result.Title != "ジェネリクス入門" || result.Description != "型パラメータの話。" {
+		t.Errorf("got title %q and description %q", result.Title, result.Description)
+	}
+	if result.Image != "https://zenn.example/og.png" || result.SiteName != "Zenn" {
+		t.Errorf("got image %q and site name %q", result.Image, result.SiteName)
+	}
+	want := &Article{
+		Published: "2024-03-01T12:00:00+09:00",
+		Authors:   []string{"Gopher"},
+		Tags:      []string{"Go"},
+		Likes:     7,
+		Sources: map[string]Source{
+			ArticleFieldPublished: SourceAPI, ArticleFieldAuthors: SourceAPI, ArticleFieldTags: SourceAPI,
+		},
+	}
+	if !reflect.DeepEqual(result.Article, want) {
+		t.Errorf("got article %+v, want %+v", result.Article, want)
+	}
+}
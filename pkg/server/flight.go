@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// flightGroup deduplicates concurrent fetches of the same URL: callers that
+// arrive while a fetch is in flight wait for and share its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	key     string
+	done    chan struct{}
+	result  *ogp.Result
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// Do starts fn for key unless a call for key is already in flight, and
+// returns a channel that is closed when the shared call completes. Every
+// caller must call Leave once it stops waiting; fn's context is cancelled
+// when the last caller leaves.
+func (g *flightGroup) Do(key string, fn func(ctx context.Context) *ogp.Result) *flightCall {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if c, ok := g.calls[key]; ok {
+		c.waiters++
+		g.mu.Unlock()
+		return c
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &flightCall{key: key, done: make(chan struct{}), cancel: cancel, waiters: 1}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	go func() {
+		c.result = fn(ctx)
+		cancel()
+		g.mu.Lock()
+		g.forget(c)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	return c
+}
+
+// Leave releases a caller of c. When no caller is left the in-flight fetch is
+// cancelled and later callers start a new one.
+func (g *flightGroup) Leave(c *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c.waiters--
+	if c.waiters == 0 {
+		c.cancel()
+		g.forget(c)
+	}
+}
+
+func (g *flightGroup) forget(c *flightCall) {
+	if g.calls[c.key] == c {
+		delete(g.calls, c.key)
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+func TestFlightGroup_Do_DeduplicatesConcurrentCalls(t *testing.T) {
+	var (
+		g       flightGroup
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+	fn := func(context.Context) *ogp.Result {
+		calls.Add(1)
+		<-release
+		return &ogp.Result{URL: "https://example.com"}
+	}
+
+	first := g.Do("https://example.com", fn)
+	var wg sync.WaitGroup
+	results := make([]*flightCall, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = g.Do("https://example.com", fn)
+		}(i)
+	}
+	wg.Wait()
+	close(release)
+
+	<-first.done
+	for _, c := range results {
+		<-c.done
+		if c.result != first.result {
+			t.Error("expected shared result")
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("got %d calls, want 1", n)
+	}
+
+	// a new call after completion fetches again
+	<-g.Do("https://example.com", func(context.Context) *ogp.Result { calls.Add(1); return &ogp.Result{} }).done
+	if n := calls.Load(); n != 2 {
+		t.Errorf("got %d calls, want 2", n)
+	}
+}
+
+func TestFlightGroup_Leave_CancelsWithoutWaiters(t *testing.T) {
+	var g flightGroup
+	fn := func(ctx context.Context) *ogp.Result {
+		<-ctx.Done()
+		return &ogp.Result{Err: ctx.Err()}
+	}
+
+	first := g.Do("https://example.com", fn)
+	second := g.Do("https://example.com", fn)
+	g.Leave(first)
+	select {
+	case <-first.done:
+		t.Fatal("call cancelled while a caller is still waiting")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	g.Leave(second)
+	<-second.done
+	if second.result.Err == nil {
+		t.Error("expected the call to be cancelled")
+	}
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tro3373/ogp/pkg/imageutil"
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+// MaxImageBytes is the largest source image the proxy accepts. The client
+// given to WithImageProxy should stop reading bodies beyond it.
+const MaxImageBytes = 20 << 20
+
+const (
+	imageProxyPath     = "/v1/image"
+	maxImageDimension  = 2048
+	imageCacheMaxAge   = 7 * 24 * 60 * 60
+	imageRequestAccept = "image/jpeg,image/png,image/webp,image/gif;q=0.9,*/*;q=0.5"
+)
+
+// imageParams are the signed parameters of an image proxy URL.
+type imageParams struct {
+	URL    string
+	Width  int
+	Height int
+	Format string
+}
+
+// WithImageProxy enables the image proxy endpoint. Images are fetched with
+// client, which should cap response bodies at MaxImageBytes, and proxy URLs
+// must be signed with secret.
+func WithImageProxy(client ogp.HTTPClient, secret []byte) Option {
+	return func(s *Server) {
+		s.imageClient = client
+		s.imageSecret = secret
+	}
+}
+
+// WithImageDecodes sets how many proxied images are decoded and resized
+// concurrently. Decoding a large image takes hundreds of megabytes.
+func WithImageDecodes(n int) Option {
+	return func(s *Server) { s.imageDecodes = make(chan struct{}, max(n, 1)) }
+}
+
+// SignImageURL returns the signed image proxy path for imageURL, resized to
+// fit within width x height (0 keeps the original size) and re-encoded to
+// format ("jpeg" or "png").
+func SignImageURL(secret []byte, imageURL string, width, height int, format string) string {
+	p := imageParams{URL: imageURL, Width: width, Height: height, Format: format}
+	q := url.Values{}
+	q.Set("url", p.URL)
+	if p.Width > 0 {
+		q.Set("w", strconv.Itoa(p.Width))
+	}
+	if p.Height > 0 {
+		q.Set("h", strconv.Itoa(p.Height))
+	}
+	if p.Format != "" {
+		q.Set("f", p.Format)
+	}
+	q.Set("s", p.signature(secret))
+	return imageProxyPath + "?" + q.Encode()
+}
+
+func (p imageParams) signature(secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%d\n%d\n%s", p.URL, p.Width, p.Height, p.Format)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func parseImageParams(q url.Values) (imageParams, error) {
+	p := imageParams{URL: q.Get("url"), Format: q.Get("f")}
+	if err := validateURL(p.URL); err != nil {
+		return p, err
+	}
+	var err error
+	if p.Width, err = parseDimension(q.Get("w")); err != nil {
+		return p, err
+	}
+	if p.Height, err = parseDimension(q.Get("h")); err != nil {
+		return p, err
+	}
+	switch p.Format {
+	case "", imageutil.FormatJPEG, imageutil.FormatPNG:
+	default:
+		return p, fmt.Errorf("unsupported format %q", p.Format)
+	}
+	return p, nil
+}
+
+func parseDimension(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 || n > maxImageDimension {
+		return 0, fmt.Errorf("invalid dimension %q (0-%d)", v, maxImageDimension)
+	}
+	return n, nil
+}
+
+func (s *Server) handleImage(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	params, err := parseImageParams(q)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	sig := params.signature(s.imageSecret)
+	if !hmac.Equal([]byte(sig), []byte(q.Get("s"))) {
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: "invalid signature"})
+		return
+	}
+
+	etag := `"` + sig + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.requestTimeout)
+	defer cancel()
+
+	out, format, status, err := s.proxyImage(ctx, params)
+	if err != nil {
+		log.Warnf("Error proxying image %s: %v", params.URL, err)
+		writeJSON(w, status, errorResponse{Error: err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", imageutil.ContentType(format))
+	w.Header().Set("Content-Length", strconv.Itoa(len(out)))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", imageCacheMaxAge))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(out); err != nil {
+		log.Warnf("failed to write image: %v", err)
+	}
+}
+
+// proxyImage fetches, validates, resizes and re-encodes an image. On failure
+// it returns the HTTP status to respond with.
+func (s *Server) proxyImage(ctx context.Context, p imageParams) ([]byte, string, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return nil, "", http.StatusBadRequest, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ogp-cli/1.0)")
+	req.Header.Set("Accept", imageRequestAccept)
+
+	body, statusCode, err := s.imageClient.Request(req)
+	if err != nil {
+		return nil, "", http.StatusBadGateway, fmt.Errorf("failed to fetch image: %w", err)
+	}
+	if statusCode >= http.StatusBadRequest {
+		return nil, "", http.StatusBadGateway, fmt.Errorf("image returned HTTP %d", statusCode)
+	}
+	if len(body) > MaxImageBytes {
+		return nil, "", http.StatusBadGateway, fmt.Errorf("image is too large (%d bytes)", len(body))
+	}
+	if mimeType, ok := imageutil.Sniff(body); !ok {
+		return nil, "", http.StatusUnsupportedMediaType, fmt.Errorf("not an image: %s", mimeType)
+	}
+
+	select {
+	case s.imageDecodes <- struct{}{}:
+		defer func() { <-s.imageDecodes }()
+	case <-ctx.Done():
+		return nil, "", http.StatusServiceUnavailable, fmt.Errorf("timed out waiting to decode image")
+	}
+
+	img, srcFormat, err := imageutil.Decode(body)
+	if err != nil {
+		return nil, "", http.StatusUnsupportedMediaType, err
+	}
+
+	format := p.Format
+	if format == "" {
+		format = imageutil.FormatJPEG
+		if srcFormat == imageutil.FormatPNG {
+			format = imageutil.FormatPNG
+		}
+	}
+	maxW, maxH := p.Width, p.Height
+	if maxW == 0 && maxH == 0 {
+		maxW, maxH = maxImageDimension, maxImageDimension
+	}
+
+	var buf bytes.Buffer
+	if err := imageutil.Encode(&buf, imageutil.Resize(img, maxW, maxH), format); err != nil {
+		return nil, "", http.StatusInternalServerError, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), format, http.StatusOK, nil
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+type fakeHTTPClient struct {
+	handler func(req *http.Request) ([]byte, int, error)
+}
+
+func (c *fakeHTTPClient) Request(req *http.Request) ([]byte, int, error) {
+	return c.handler(req)
+}
+
+var testSecret = []byte("secret")
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: 10, G: 20, B: 30, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func newImageServer(t *testing.T) *Server {
+	t.Helper()
+	pngData := testPNG(t, 400, 200)
+	client := &fakeHTTPClient{
+		handler: func(req *http.Request) ([]byte, int, error) {
+			switch req.URL.Path {
+			case "/img.png":
+				return pngData, http.StatusOK, nil
+			case "/page.html":
+				return []byte("<html><body>not an image</body></html>"), http.StatusOK, nil
+			}
+			return []byte("Not Found"), http.StatusNotFound, nil
+		},
+	}
+	return New(newTestFetcher(), WithImageProxy(client, testSecret))
+}
+
+func TestServer_Image_ResizesAndReencodes(t *testing.T) {
+	srv := newImageServer(t)
+	path := SignImageURL(testSecret, "https://example.com/img.png", 100, 0, "jpeg")
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "image/jpeg" {
+		t.Errorf("got content type %q", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); !strings.Contains(got, "max-age=") {
+		t.Errorf("got cache control %q", got)
+	}
+	cfg, format, err := image.DecodeConfig(rec.Body)
+	if err != nil {
+		t.Fatalf("invalid image: %v", err)
+	}
+	if format != "jpeg" || cfg.Width != 100 || cfg.Height != 50 {
+		t.Errorf("got %s %dx%d, want jpeg 100x50", format, cfg.Width, cfg.Height)
+	}
+
+	etag := rec.Header().Get("ETag")
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusNotModified)
+	}
+}
+
+func TestServer_Image_Errors(t *testing.T) {
+	tests := map[string]struct {
+		path       string
+		wantStatus int
+	}{
+		"invalid signature": {
+			path:       SignImageURL([]byte("other"), "https://example.com/img.png", 100, 0, ""),
+			wantStatus: http.StatusForbidden,
+		},
+		"tampered size": {
+			path:       strings.Replace(SignImageURL(testSecret, "https://example.com/img.png", 100, 0, ""), "w=100", "w=200", 1),
+			wantStatus: http.StatusForbidden,
+		},
+		"not an image": {
+			path:       SignImageURL(testSecret, "https://example.com/page.html", 0, 0, ""),
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		"upstream error": {
+			path:       SignImageURL(testSecret, "https://example.com/missing.png", 0, 0, ""),
+			wantStatus: http.StatusBadGateway,
+		},
+		"too large dimension": {
+			path:       SignImageURL(testSecret, "https://example.com/img.png", 5000, 0, ""),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	srv := newImageServer(t)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != tc.wantStatus {
+				t.Errorf("got status %d, want %d: %s", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_Preview_IncludesImageProxy(t *testing.T) {
+	fetcher := newTestFetcher()
+	base := fetcher.fetch
+	fetcher.fetch = func(ctx context.Context, targetURL string) *ogp.Result {
+		r := base(ctx, targetURL)
+		r.Image = "https://example.com/img.png"
+		return r
+	}
+	srv := New(fetcher, WithImageProxy(&fakeHTTPClient{}, testSecret))
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/preview?url=https://example.com", nil))
+
+	var body struct {
+		ImageProxy string `json:"image_proxy"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := SignImageURL(testSecret, "https://example.com/img.png", 0, 0, "")
+	if body.ImageProxy != want {
+		t.Errorf("got image_proxy %q, want %q", body.ImageProxy, want)
+	}
+}
+
+func TestServer_Image_WaitsForDecodeSlot(t *testing.T) {
+	srv := newImageServer(t)
+	WithImageDecodes(1)(srv)
+	WithRequestTimeout(10 * time.Millisecond)(srv)
+	srv.imageDecodes <- struct{}{}
+
+	rec := httptest.NewRecorder()
+	path := SignImageURL(testSecret, "https://example.com/img.png", 0, 0, "")
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	<-srv.imageDecodes
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+const (
+	defaultRequestTimeout  = 15 * time.Second
+	defaultShutdownTimeout = 10 * time.Second
+	defaultMaxBatch        = 20
+	defaultBatchWorkers    = 4
+	defaultImageDecodes    = 2
+	maxRequestBodyBytes    = 1 << 20
+)
+
+// Fetcher fetches OGP metadata for a URL.
+type Fetcher interface {
+	Fetch(ctx context.Context, targetURL string) *ogp.Result
+}
+
+// Server is the link-preview HTTP API backed by a shared Fetcher.
+type Server struct {
+	fetcher         Fetcher
+	requestTimeout  time.Duration
+	shutdownTimeout time.Duration
+	maxBatch        int
+	batchWorkers    int
+	imageClient     ogp.HTTPClient
+	imageSecret     []byte
+	imageDecodes    chan struct{}
+	flights         flightGroup
+	ready           atomic.Bool
+}
+
+// Option applies a configuration to a Server.
+type Option func(*Server)
+
+// WithRequestTimeout sets the maximum time spent fetching for one API request.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(s *Server) { s.requestTimeout = d }
+}
+
+// WithShutdownTimeout sets how long graceful shutdown waits for in-flight requests.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(s *Server) { s.shutdownTimeout = d }
+}
+
+// WithMaxBatch sets the maximum number of URLs accepted by the batch endpoint.
+func WithMaxBatch(n int) Option {
+	return func(s *Server) { s.maxBatch = n }
+}
+
+// WithBatchWorkers sets how many URLs of a batch are fetched concurrently.
+func WithBatchWorkers(n int) Option {
+	return func(s *Server) { s.batchWorkers = n }
+}
+
+// New creates a Server using the given fetcher.
+func New(fetcher Fetcher, opts ...Option) *Server {
+	s := &Server{
+		fetcher:         fetcher,
+		requestTimeout:  defaultRequestTimeout,
+		shutdownTimeout: defaultShutdownTimeout,
+		maxBatch:        defaultMaxBatch,
+		batchWorkers:    defaultBatchWorkers,
+		imageDecodes:    make(chan struct{}, defaultImageDecodes),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ready.Store(true)
+	return s
+}
+
+// Preview is the API representation of a fetched result. Failed fetches carry
+// the URL and an error message instead of metadata.
+type Preview struct {
+	*ogp.Result
+	// ImageProxy is the signed image proxy path for Image, when the image
+	// proxy is enabled.
+	ImageProxy string `json:"image_proxy,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type batchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+type batchResponse struct {
+	Results []*Preview `json:"results"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns the HTTP handler serving the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/preview", s.handlePreview)
+	mux.HandleFunc("POST /v1/preview", s.handleBatchPreview)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /readyz", s.handleReady)
+	if s.imageClient != nil {
+		mux.HandleFunc("GET "+imageProxyPath, s.handleImage)
+	}
+	return mux
+}
+
+// ListenAndServe serves the API on addr until ctx is cancelled, then shuts
+// down gracefully, waiting for in-flight requests up to the shutdown timeout.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      s.requestTimeout + 10*time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Infof("Listening on %s", addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Info("Shutting down")
+	s.ready.Store(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server stopped: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) handlePreview(w http.ResponseWriter, r *http.Request) {
+	targetURL := r.URL.Query().Get("url")
+	if err := validateURL(targetURL); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.requestTimeout)
+	defer cancel()
+
+	preview, err := s.fetch(ctx, targetURL)
+	if err != nil {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: err.Error()})
+		return
+	}
+	status := http.StatusOK
+	if preview.Error != "" {
+		status = http.StatusBadGateway
+	}
+	writeJSON(w, status, preview)
+}
+
+func (s *Server) handleBatchPreview(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+	if len(req.URLs) == 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "no url provided"})
+		return
+	}
+	if len(req.URLs) > s.maxBatch {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("too many urls: %d (max %d)", len(req.URLs), s.maxBatch)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.requestTimeout)
+	defer cancel()
+
+	writeJSON(w, http.StatusOK, batchResponse{Results: s.fetchBatch(ctx, req.URLs)})
+}
+
+func (s *Server) fetchBatch(ctx context.Context, urls []string) []*Preview {
+	var wg sync.WaitGroup
+	previews := make([]*Preview, len(urls))
+	sem := make(chan struct{}, s.batchWorkers)
+
+	for i, u := range urls {
+		if err := validateURL(u); err != nil {
+			previews[i] = errorPreview(u, err)
+			continue
+		}
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			preview, err := s.fetch(ctx, u)
+			if err != nil {
+				preview = errorPreview(u, err)
+			}
+			previews[i] = preview
+		}(i, u)
+	}
+
+	wg.Wait()
+	return previews
+}
+
+// fetch fetches targetURL, sharing the result with concurrent identical
+// requests. It returns an error when ctx ends before the fetch completes; the
+// fetch itself is cancelled once no request is waiting for it.
+func (s *Server) fetch(ctx context.Context, targetURL string) (*Preview, error) {
+	call := s.flights.Do(targetURL, func(ctx context.Context) *ogp.Result {
+		log.Debugf("Fetching URL: %s", targetURL)
+		return s.fetcher.Fetch(ctx, targetURL)
+	})
+	defer s.flights.Leave(call)
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out fetching %s", targetURL)
+	}
+
+	if call.result.Err != nil {
+		log.Warnf("Error fetching %s: %v", targetURL, call.result.Err)
+		return errorPreview(targetURL, call.result.Err), nil
+	}
+	preview := &Preview{Result: call.result}
+	if s.imageClient != nil && call.result.Image != "" {
+		preview.ImageProxy = SignImageURL(s.imageSecret, call.result.Image, 0, 0, "")
+	}
+	return preview, nil
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, _ *http.Request) {
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "shutting down"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func errorPreview(targetURL string, err error) *Preview {
+	return &Preview{Result: &ogp.Result{URL: targetURL}, Error: err.Error()}
+}
+
+func validateURL(targetURL string) error {
+	if targetURL == "" {
+		return fmt.Errorf("no url provided")
+	}
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", targetURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q: must be an absolute http(s) URL", targetURL)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warnf("failed to write response: %v", err)
+	}
+}
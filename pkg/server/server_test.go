@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tro3373/ogp/pkg/ogp"
+)
+
+type fakeFetcher struct {
+	fetch func(ctx context.Context, targetURL string) *ogp.Result
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, targetURL string) *ogp.Result {
+	return f.fetch(ctx, targetURL)
+}
+
+func newTestFetcher() *fakeFetcher {
+	return &fakeFetcher{
+		fetch: func(_ context.Context, targetURL string) *ogp.Result {
+			if strings.Contains(targetURL, "broken") {
+				return &ogp.Result{URL: targetURL, Err: fmt.Errorf("HTTP 404 for %s", targetURL)}
+			}
+			return &ogp.Result{URL: targetURL, Title: "Title of " + targetURL}
+		},
+	}
+}
+
+func TestServer_Preview(t *testing.T) {
+	tests := map[string]struct {
+		url        string
+		wantStatus int
+		wantTitle  string
+		wantError  bool
+	}{
+		"success": {
+			url:        "https://example.com",
+			wantStatus: http.StatusOK,
+			wantTitle:  "Title of https://example.com",
+		},
+		"fetch error": {
+			url:        "https://broken.example.com",
+			wantStatus: http.StatusBadGateway,
+			wantError:  true,
+		},
+		"missing url": {
+			url:        "",
+			wantStatus: http.StatusBadRequest,
+			wantError:  true,
+		},
+		"unsupported scheme": {
+			url:        "file:///etc/passwd",
+			wantStatus: http.StatusBadRequest,
+			wantError:  true,
+		},
+	}
+
+	srv := New(newTestFetcher())
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/preview?url="+url.QueryEscape(tc.url), nil)
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if tc.wantTitle != "" && body["title"] != tc.wantTitle {
+				t.Errorf("got title %v, want %q", body["title"], tc.wantTitle)
+			}
+			if _, ok := body["error"]; ok != tc.wantError {
+				t.Errorf("got error field present=%v, want %v: %v", ok, tc.wantError, body)
+			}
+		})
+	}
+}
+
+func TestServer_BatchPreview(t *testing.T) {
+	srv := New(newTestFetcher())
+	body := `{"urls":["https://a.example.com","https://broken.example.com","ftp://c.example.com"]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/preview", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Results []struct {
+			URL   string `json:"url"`
+			Title string `json:"title"`
+			Error string `json:"error"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(resp.Results))
+	}
+	if resp.Results[0].Title != "Title of https://a.example.com" || resp.Results[0].Error != "" {
+		t.Errorf("unexpected first result: %+v", resp.Results[0])
+	}
+	if resp.Results[1].URL != "https://broken.example.com" || resp.Results[1].Error == "" {
+		t.Errorf("unexpected second result: %+v", resp.Results[1])
+	}
+	if resp.Results[2].Error == "" {
+		t.Errorf("expected validation error: %+v", resp.Results[2])
+	}
+}
+
+func TestServer_BatchPreview_TooMany(t *testing.T) {
+	srv := New(newTestFetcher(), WithMaxBatch(1))
+	body := `{"urls":["https://a.example.com","https://b.example.com"]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/preview", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServer_Preview_Timeout(t *testing.T) {
+	cancelled := make(chan struct{})
+	fetcher := &fakeFetcher{
+		fetch: func(ctx context.Context, targetURL string) *ogp.Result {
+			<-ctx.Done()
+			close(cancelled)
+			return &ogp.Result{URL: targetURL, Err: ctx.Err()}
+		},
+	}
+	srv := New(fetcher, WithRequestTimeout(10*time.Millisecond))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/preview?url=https://slow.example.com", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusGatewayTimeout)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected the in-flight fetch to be cancelled")
+	}
+}
+
+func TestServer_HealthAndReady(t *testing.T) {
+	srv := New(newTestFetcher())
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: got status %d, want %d", path, rec.Code, http.StatusOK)
+		}
+	}
+
+	srv.ready.Store(false)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}